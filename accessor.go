@@ -0,0 +1,85 @@
+package code
+
+import (
+	"github.com/dave/jennifer/jen"
+)
+
+// AccessorOptions is used when you call AddAccessors, it is a handy way to allow multiple configurations
+// for the generated accessors.
+type AccessorOptions func(o *accessorOptions)
+
+type accessorOptions struct {
+	setters bool
+	tagKey  string
+}
+
+// SettersAccessorOption also adds a Set{Field} method for every field.
+func SettersAccessorOption() AccessorOptions {
+	return func(o *accessorOptions) {
+		o.setters = true
+	}
+}
+
+// TagAccessorOption sets the tag key used to opt out of the accessors, the default key is accessor.
+func TagAccessorOption(key string) AccessorOptions {
+	return func(o *accessorOptions) {
+		o.tagKey = key
+	}
+}
+
+// AddAccessors adds accessor methods for the exported fields to the structure and returns the added methods.
+//
+// For every field it adds a Get{Field} method that is safe to call on a nil receiver,
+// it returns the zero value of the field in that case (like protobuf getters).
+// With SettersAccessorOption it also adds a Set{Field} method.
+// Fields can opt out with a tag, `accessor:"-"` skips the field and `accessor:"readonly"` skips its setter.
+func AddAccessors(st *Struct, options ...AccessorOptions) []*Function {
+	opts := &accessorOptions{
+		tagKey: "accessor",
+	}
+	for _, o := range options {
+		o(opts)
+	}
+	// zero and the setter parameters are used by the generated code.
+	used := []string{"zero"}
+	for _, f := range st.Fields {
+		used = append(used, paramName(fieldName(f)))
+	}
+	recv := freeReceiverName(st.Name, used...)
+	var methods []*Function
+	for _, f := range st.Fields {
+		field := fieldName(f)
+		if !isExported(field) || hasTagOption(f, opts.tagKey, "-") {
+			continue
+		}
+		zero := []jen.Code{jen.Return(jen.Id("zero"))}
+		if v, ok := zeroValue(f.Type); ok {
+			zero = []jen.Code{jen.Return(v)}
+		} else {
+			zero = append([]jen.Code{jen.Var().Id("zero").Add(f.Type.Code())}, zero...)
+		}
+		methods = append(methods, NewFunction(
+			"Get"+field,
+			ResultsFunctionOption(*NewParameter("", f.Type.Clone())),
+			BodyFunctionOption(
+				jen.If(jen.Id(recv).Op("==").Nil()).Block(zero...),
+				jen.Return(jen.Id(recv).Dot(field)),
+			),
+			DocsFunctionOption(Comment("Get"+field+" returns the "+field+" of the "+st.Name+", it returns the zero value if the "+st.Name+" is nil.")),
+		))
+		if !opts.setters || hasTagOption(f, opts.tagKey, "readonly") {
+			continue
+		}
+		param := paramName(field)
+		methods = append(methods, NewFunction(
+			"Set"+field,
+			ParamsFunctionOption(*NewParameter(param, f.Type.Clone())),
+			BodyFunctionOption(jen.Id(recv).Dot(field).Op("=").Id(param)),
+			DocsFunctionOption(Comment("Set"+field+" sets the "+field+" of the "+st.Name+".")),
+		))
+	}
+	for _, m := range methods {
+		st.AddMethod(m, ReceiverNameMethodOption(recv))
+	}
+	return methods
+}
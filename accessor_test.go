@@ -0,0 +1,145 @@
+package code
+
+import (
+	"testing"
+)
+
+func TestAddAccessors(t *testing.T) {
+	newUser := func() *Struct {
+		return NewStructWithFields("User", []StructField{
+			*NewStructField("Name", NewType("string")),
+			*NewStructFieldWithTag("ID", NewType("UUID", ImportTypeOption(*NewImport("", "github.com/google/uuid"))), NewFieldTags("accessor", "readonly")),
+			*NewStructFieldWithTag("Password", NewType("string"), NewFieldTags("accessor", "-")),
+			*NewStructField("tags", NewType("", ArrayTypeOption(NewType("string")))),
+		})
+	}
+	tests := []struct {
+		name    string
+		st      func() *Struct
+		options []AccessorOptions
+		want    string
+	}{
+		{
+			name: "Should add nil safe getters",
+			st:   newUser,
+			want: `package test
+
+import uuid "github.com/google/uuid"
+
+type User struct {
+	Name     string
+	ID       uuid.UUID ` + "`accessor:\"readonly\"`" + `
+	Password string    ` + "`accessor:\"-\"`" + `
+	tags     []string
+}
+
+// GetName returns the Name of the User, it returns the zero value if the User is nil.
+func (u *User) GetName() string {
+	if u == nil {
+		return ""
+	}
+	return u.Name
+}
+
+// GetID returns the ID of the User, it returns the zero value if the User is nil.
+func (u *User) GetID() uuid.UUID {
+	if u == nil {
+		var zero uuid.UUID
+		return zero
+	}
+	return u.ID
+}
+`,
+		},
+		{
+			name:    "Should add setters",
+			st:      newUser,
+			options: []AccessorOptions{SettersAccessorOption()},
+			want: `package test
+
+import uuid "github.com/google/uuid"
+
+type User struct {
+	Name     string
+	ID       uuid.UUID ` + "`accessor:\"readonly\"`" + `
+	Password string    ` + "`accessor:\"-\"`" + `
+	tags     []string
+}
+
+// GetName returns the Name of the User, it returns the zero value if the User is nil.
+func (u *User) GetName() string {
+	if u == nil {
+		return ""
+	}
+	return u.Name
+}
+
+// SetName sets the Name of the User.
+func (u *User) SetName(name string) {
+	u.Name = name
+}
+
+// GetID returns the ID of the User, it returns the zero value if the User is nil.
+func (u *User) GetID() uuid.UUID {
+	if u == nil {
+		var zero uuid.UUID
+		return zero
+	}
+	return u.ID
+}
+`,
+		},
+		{
+			name: "Should not use the setter parameters as the receiver",
+			st: func() *Struct {
+				return NewStructWithFields("Item", []StructField{*NewStructField("I", NewType("int")), *NewStructField("Item", NewType("string"))})
+			},
+			options: []AccessorOptions{SettersAccessorOption()},
+			want: `package test
+
+type Item struct {
+	I    int
+	Item string
+}
+
+// GetI returns the I of the Item, it returns the zero value if the Item is nil.
+func (recv *Item) GetI() int {
+	if recv == nil {
+		return 0
+	}
+	return recv.I
+}
+
+// SetI sets the I of the Item.
+func (recv *Item) SetI(i int) {
+	recv.I = i
+}
+
+// GetItem returns the Item of the Item, it returns the zero value if the Item is nil.
+func (recv *Item) GetItem() string {
+	if recv == nil {
+		return ""
+	}
+	return recv.Item
+}
+
+// SetItem sets the Item of the Item.
+func (recv *Item) SetItem(item string) {
+	recv.Item = item
+}
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := tt.st()
+			methods := AddAccessors(st, tt.options...)
+			if len(methods) != len(st.Methods()) {
+				t.Errorf("AddAccessors() returned %d methods, attached %d", len(methods), len(st.Methods()))
+			}
+			if got := NewFile("test", st).String(); got != tt.want {
+				t.Errorf("AddAccessors() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,155 @@
+package code
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/dave/jennifer/jen"
+)
+
+// NewBenchmark creates the benchmark of the function or method (e.x BenchmarkSum for Sum, Benchmark_sum for sum
+// and BenchmarkUser_Name for the Name method of User).
+//
+// The arguments of the function are declared with their zero values before the benchmark loop,
+// methods are called on a zero value of the receiver type. The benchmark reports the allocations (b.ReportAllocs).
+// An error is returned if ImportTestOption is set and the function or one of the used package types are unexported.
+func NewBenchmark(fn *Function, options ...TestOptions) (*Function, error) {
+	opts := &testOptions{}
+	for _, o := range options {
+		o(opts)
+	}
+	recv, err := testReceiver(fn, opts)
+	if err != nil {
+		return nil, err
+	}
+	params := testParams(fn, "b", "i", "receiver")
+	var body, vars, args []jen.Code
+	if fn.Recv != nil {
+		recv.Pointer = false
+		vars = append(vars, jen.Id("receiver").Add(recv.Code()))
+	}
+	for _, p := range params {
+		tp, err := qualifyType(p.Type, opts.importPath)
+		if err != nil {
+			return nil, err
+		}
+		arg := jen.Id(p.Name)
+		if tp.Variadic {
+			tp.Variadic = false
+			tp = NewType("", ArrayTypeOption(tp))
+			arg.Op("...")
+		}
+		vars = append(vars, jen.Id(p.Name).Add(tp.Code()))
+		args = append(args, arg)
+	}
+	switch len(vars) {
+	case 0:
+	case 1:
+		body = append(body, jen.Var().Add(vars[0]))
+	default:
+		body = append(body, jen.Var().Defs(vars...))
+	}
+	body = append(body,
+		jen.Id("b").Dot("ReportAllocs").Call(),
+		jen.Id("b").Dot("ResetTimer").Call(),
+		jen.For(jen.Id("i").Op(":=").Lit(0), jen.Id("i").Op("<").Id("b").Dot("N"), jen.Id("i").Op("++")).Block(
+			testCall(fn, jen.Id("receiver"), args, opts),
+		),
+	)
+	return NewFunction(
+		testName("Benchmark", fn),
+		ParamsFunctionOption(*NewParameter("b", NewType("B", ImportTypeOption(Import{Path: "testing"}), PointerTypeOption()))),
+		BodyFunctionOption(body...),
+	), nil
+}
+
+// NewFuzzTest creates the fuzz test of the function or method (e.x FuzzSum for Sum, Fuzz_sum for sum
+// and FuzzUser_Name for the Name method of User).
+//
+// The parameters of the function must be fuzzable types (string, []byte, bool, integers except uintptr and floats),
+// they are the arguments of the fuzz target. The seed corpus has an entry with the zero values and an entry with
+// sample values (e.x "seed" and 1), methods are called on a zero value of the receiver type.
+// The checks of the results are left to add.
+// An error is returned if the function has no parameters, if a parameter is not fuzzable or if ImportTestOption is set and the function
+// or the receiver type are unexported.
+func NewFuzzTest(fn *Function, options ...TestOptions) (*Function, error) {
+	opts := &testOptions{}
+	for _, o := range options {
+		o(opts)
+	}
+	recv, err := testReceiver(fn, opts)
+	if err != nil {
+		return nil, err
+	}
+	params := testParams(fn, "f", "t", "receiver")
+	if len(params) == 0 {
+		return nil, fmt.Errorf("the function %s has no parameters to fuzz", fn.Name)
+	}
+	var zero, sample, fuzzParams, args []jen.Code
+	for _, p := range params {
+		z, s, ok := fuzzSeeds(p.Type)
+		if !ok {
+			return nil, fmt.Errorf("the parameter %s: the type %s can not be fuzzed", p.Name, p.Type.String())
+		}
+		zero = append(zero, z)
+		sample = append(sample, s)
+		fuzzParams = append(fuzzParams, jen.Id(p.Name).Add(p.Type.Code()))
+		args = append(args, jen.Id(p.Name))
+	}
+	var target []jen.Code
+	if fn.Recv != nil {
+		recv.Pointer = false
+		target = append(target, jen.Var().Id("receiver").Add(recv.Code()))
+	}
+	target = append(target, testCall(fn, jen.Id("receiver"), args, opts))
+	if len(fn.Results) > 0 {
+		target = append(target, jen.Comment("TODO: Check the results."))
+	}
+	body := []jen.Code{
+		jen.Id("f").Dot("Add").Call(zero...),
+		jen.Id("f").Dot("Add").Call(sample...),
+		jen.Id("f").Dot("Fuzz").Call(
+			jen.Func().Params(append([]jen.Code{jen.Id("t").Op("*").Qual("testing", "T")}, fuzzParams...)...).Block(target...),
+		),
+	}
+	return NewFunction(
+		testName("Fuzz", fn),
+		ParamsFunctionOption(*NewParameter("f", NewType("F", ImportTypeOption(Import{Path: "testing"}), PointerTypeOption()))),
+		BodyFunctionOption(body...),
+	), nil
+}
+
+// testParams returns the parameters of the function with a name that is not used by the test,
+// unnamed parameters and parameters that use one of the names get the name arg{index} (e.x arg0).
+func testParams(fn *Function, used ...string) []Parameter {
+	params := namedParams(fn.Params, "arg")
+	for i := range params {
+		for _, u := range used {
+			if params[i].Name == u {
+				params[i].Name = "arg" + strconv.Itoa(i)
+			}
+		}
+	}
+	return params
+}
+
+// fuzzSeeds returns the zero value and a sample value of the fuzzable type,
+// it returns false if the type can not be fuzzed.
+func fuzzSeeds(tp Type) (jen.Code, jen.Code, bool) {
+	if tp.Variadic || tp.Qualifier == "uintptr" {
+		return nil, nil, false
+	}
+	switch basicKind(tp) {
+	case "string":
+		return jen.Lit(""), jen.Lit("seed"), true
+	case "bytes":
+		return jen.Index().Byte().Call(jen.Lit("")), jen.Index().Byte().Call(jen.Lit("seed")), true
+	case "bool":
+		return jen.False(), jen.True(), true
+	case "int", "uint":
+		return convert(tp.Qualifier, NewType("int"), jen.Lit(0)), convert(tp.Qualifier, NewType("int"), jen.Lit(1)), true
+	case "float":
+		return convert(tp.Qualifier, NewType("float64"), jen.Lit(0.0)), convert(tp.Qualifier, NewType("float64"), jen.Lit(1.5)), true
+	}
+	return nil, nil, false
+}
@@ -0,0 +1,154 @@
+package code
+
+import (
+	"testing"
+)
+
+func TestNewBenchmark(t *testing.T) {
+	user := NewStruct("User")
+	name := NewFunction("Name", ParamsFunctionOption(*NewParameter("b", NewType("string"))), ResultsFunctionOption(*NewParameter("", NewType("string"))))
+	user.AddMethod(name)
+	tests := []struct {
+		name    string
+		fn      *Function
+		options []TestOptions
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "Should create the benchmark of a function",
+			fn: NewFunction(
+				"sum",
+				ParamsFunctionOption(*NewParameter("xs", NewType("Duration", ImportTypeOption(*NewImport("", "time")), VariadicTypeOption()))),
+			),
+			want: `package foo
+
+import (
+	"testing"
+	"time"
+)
+
+func Benchmark_sum(b *testing.B) {
+	var xs []time.Duration
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		sum(xs...)
+	}
+}
+`,
+		},
+		{
+			name:    "Should create the benchmark of a method in an external test package",
+			fn:      name,
+			options: []TestOptions{ImportTestOption("github.com/test/foo")},
+			want: `package foo
+
+import (
+	foo "github.com/test/foo"
+	"testing"
+)
+
+func BenchmarkUser_Name(b *testing.B) {
+	var (
+		receiver foo.User
+		arg0     string
+	)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		receiver.Name(arg0)
+	}
+}
+`,
+		},
+		{
+			name:    "Should return an error for unexported functions in external test packages",
+			fn:      NewFunction("sum"),
+			options: []TestOptions{ImportTestOption("github.com/test/foo")},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewBenchmark(tt.fn, tt.options...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewBenchmark() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if s := NewFile("foo", got).String(); s != tt.want {
+				t.Errorf("NewBenchmark() = %v, want %v", s, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewFuzzTest(t *testing.T) {
+	tests := []struct {
+		name    string
+		fn      *Function
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "Should create the fuzz test of a function",
+			fn: NewFunction(
+				"Parse",
+				ParamsFunctionOption(
+					*NewParameter("s", NewType("string")),
+					*NewParameter("data", NewType("", ArrayTypeOption(NewType("byte")))),
+					*NewParameter("strict", NewType("bool")),
+					*NewParameter("n", NewType("int")),
+					*NewParameter("u", NewType("uint8")),
+					*NewParameter("x", NewType("float64")),
+					*NewParameter("t", NewType("float32")),
+				),
+				ResultsFunctionOption(*NewParameter("", NewType("error"))),
+			),
+			want: `package foo
+
+import "testing"
+
+func FuzzParse(f *testing.F) {
+	f.Add("", []byte(""), false, 0, uint8(0), 0.0, float32(0.0))
+	f.Add("seed", []byte("seed"), true, 1, uint8(1), 1.5, float32(1.5))
+	f.Fuzz(func(t *testing.T, s string, data []byte, strict bool, n int, u uint8, x float64, arg6 float32) {
+		Parse(s, data, strict, n, u, x, arg6)
+		// TODO: Check the results.
+	})
+}
+`,
+		},
+		{
+			name:    "Should return an error for types that can not be fuzzed",
+			fn:      NewFunction("Parse", ParamsFunctionOption(*NewParameter("d", NewType("Duration", ImportTypeOption(*NewImport("", "time")))))),
+			wantErr: true,
+		},
+		{
+			name:    "Should return an error without parameters",
+			fn:      NewFunction("Reset"),
+			wantErr: true,
+		},
+		{
+			name:    "Should return an error for variadic parameters",
+			fn:      NewFunction("Parse", ParamsFunctionOption(*NewParameter("s", NewType("string", VariadicTypeOption())))),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewFuzzTest(tt.fn)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewFuzzTest() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if s := NewFile("foo", got).String(); s != tt.want {
+				t.Errorf("NewFuzzTest() = %v, want %v", s, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package code
+
+import (
+	"github.com/dave/jennifer/jen"
+)
+
+// BuilderOptions is used when you call NewBuilder, it is a handy way to allow multiple configurations
+// for the generated builder.
+type BuilderOptions func(o *builderOptions)
+
+type builderOptions struct {
+	tagKey    string
+	tagOption string
+	hooks     bool
+}
+
+// RequiredBuilderOption sets the tag that marks the required fields, the default is `builder:"required"`.
+func RequiredBuilderOption(key, option string) BuilderOptions {
+	return func(o *builderOptions) {
+		o.tagKey = key
+		o.tagOption = option
+	}
+}
+
+// HooksBuilderOption adds a Validate method to the builder that registers validation hooks,
+// the hooks are called by Build with the built value.
+func HooksBuilderOption() BuilderOptions {
+	return func(o *builderOptions) {
+		o.hooks = true
+	}
+}
+
+// NewBuilder creates a fluent builder for the structure.
+//
+// It returns a {Name}Builder structure with a Set{Field} method for every field that returns the builder,
+// a New{Name}Builder constructor and a Build() (*{Name}, error) method.
+// Build returns an error that lists all the required fields that were not set,
+// the required fields are marked with a tag (e.x `builder:"required"`, see RequiredBuilderOption).
+func NewBuilder(st *Struct, options ...BuilderOptions) []Code {
+	opts := &builderOptions{
+		tagKey:    "builder",
+		tagOption: "required",
+	}
+	for _, o := range options {
+		o(opts)
+	}
+	name := st.Name + "Builder"
+	recv := "b"
+	for _, f := range st.Fields {
+		if paramName(fieldName(f)) == recv {
+			recv = "builder"
+		}
+	}
+	builder := NewStructWithFields(
+		name,
+		[]StructField{*NewStructField("value", NewType(st.Name))},
+		Comment(name+" builds a "+st.Name+"."),
+	)
+	var required []jen.Code
+	for _, f := range st.Fields {
+		field := fieldName(f)
+		param := paramName(field)
+		body := []jen.Code{jen.Id(recv).Dot("value").Dot(field).Op("=").Id(param)}
+		if hasTagOption(f, opts.tagKey, opts.tagOption) {
+			set := unexportedName(field) + "Set"
+			builder.Fields = append(builder.Fields, *NewStructField(set, NewType("bool")))
+			body = append(body, jen.Id(recv).Dot(set).Op("=").True())
+			required = append(required, jen.If(jen.Op("!").Id(recv).Dot(set)).Block(
+				jen.Id("missing").Op("=").Append(jen.Id("missing"), jen.Lit(field)),
+			))
+		}
+		body = append(body, jen.Return(jen.Id(recv)))
+		builder.AddMethod(NewFunction(
+			"Set"+exportedName(field),
+			ParamsFunctionOption(*NewParameter(param, f.Type.Clone())),
+			ResultsFunctionOption(*NewParameter("", NewType(name, PointerTypeOption()))),
+			BodyFunctionOption(body...),
+			DocsFunctionOption(Comment("Set"+exportedName(field)+" sets the "+field+" of the "+st.Name+".")),
+		), ReceiverNameMethodOption(recv))
+	}
+
+	hook := NewType("", FunctionTypeOption(NewFunctionType(
+		ParamsFunctionOption(*NewParameter("", NewType(st.Name, PointerTypeOption()))),
+		ResultsFunctionOption(*NewParameter("", NewType("error"))),
+	)))
+	if opts.hooks {
+		builder.Fields = append(builder.Fields, *NewStructField("hooks", NewType("", ArrayTypeOption(hook))))
+		builder.AddMethod(NewFunction(
+			"Validate",
+			ParamsFunctionOption(*NewParameter("hook", hook.Clone())),
+			ResultsFunctionOption(*NewParameter("", NewType(name, PointerTypeOption()))),
+			BodyFunctionOption(
+				jen.Id(recv).Dot("hooks").Op("=").Append(jen.Id(recv).Dot("hooks"), jen.Id("hook")),
+				jen.Return(jen.Id(recv)),
+			),
+			DocsFunctionOption(Comment("Validate adds a validation hook that is called by Build.")),
+		), ReceiverNameMethodOption(recv))
+	}
+
+	var build []jen.Code
+	if len(required) > 0 {
+		build = append(build, jen.Var().Id("missing").Index().String())
+		build = append(build, required...)
+		build = append(build, jen.If(jen.Len(jen.Id("missing")).Op(">").Lit(0)).Block(
+			jen.Return(jen.Nil(), jen.Qual("fmt", "Errorf").Call(
+				jen.Lit("missing required fields of "+st.Name+": %s"),
+				jen.Qual("strings", "Join").Call(jen.Id("missing"), jen.Lit(", ")),
+			)),
+		))
+	}
+	build = append(build, jen.Id("v").Op(":=").Id(recv).Dot("value"))
+	if opts.hooks {
+		build = append(build, jen.For(jen.List(jen.Id("_"), jen.Id("hook")).Op(":=").Range().Id(recv).Dot("hooks")).Block(
+			jen.If(jen.Err().Op(":=").Id("hook").Call(jen.Op("&").Id("v")), jen.Err().Op("!=").Nil()).Block(
+				jen.Return(jen.Nil(), jen.Err()),
+			),
+		))
+	}
+	build = append(build, jen.Return(jen.Op("&").Id("v"), jen.Nil()))
+	builder.AddMethod(NewFunction(
+		"Build",
+		ResultsFunctionOption(
+			*NewParameter("", NewType(st.Name, PointerTypeOption())),
+			*NewParameter("", NewType("error")),
+		),
+		BodyFunctionOption(build...),
+		DocsFunctionOption(Comment("Build returns the "+st.Name+", it returns an error if a required field is not set.")),
+	), ReceiverNameMethodOption(recv))
+
+	constructor := NewFunction(
+		"New"+exportedName(name),
+		ResultsFunctionOption(*NewParameter("", NewType(name, PointerTypeOption()))),
+		BodyFunctionOption(jen.Return(jen.Op("&").Id(name).Values())),
+		DocsFunctionOption(Comment("New"+exportedName(name)+" creates a new "+name+".")),
+	)
+	return []Code{builder, constructor}
+}
@@ -0,0 +1,114 @@
+package code
+
+import (
+	"testing"
+)
+
+func TestNewBuilder(t *testing.T) {
+	tests := []struct {
+		name    string
+		st      *Struct
+		options []BuilderOptions
+		want    string
+	}{
+		{
+			name: "Should create a builder without required fields",
+			st:   NewStructWithFields("User", []StructField{*NewStructField("Age", NewType("int"))}),
+			want: `package test
+
+// UserBuilder builds a User.
+type UserBuilder struct {
+	value User
+}
+
+// SetAge sets the Age of the User.
+func (b *UserBuilder) SetAge(age int) *UserBuilder {
+	b.value.Age = age
+	return b
+}
+
+// Build returns the User, it returns an error if a required field is not set.
+func (b *UserBuilder) Build() (*User, error) {
+	v := b.value
+	return &v, nil
+}
+
+// NewUserBuilder creates a new UserBuilder.
+func NewUserBuilder() *UserBuilder {
+	return &UserBuilder{}
+}
+`,
+		},
+		{
+			name: "Should check the required fields and call the hooks",
+			st: NewStructWithFields("User", []StructField{
+				*NewStructFieldWithTag("Name", NewType("string"), NewFieldTags("required", "true")),
+				*NewStructField("B", NewType("bool")),
+			}),
+			options: []BuilderOptions{RequiredBuilderOption("required", "true"), HooksBuilderOption()},
+			want: `package test
+
+import (
+	"fmt"
+	"strings"
+)
+
+// UserBuilder builds a User.
+type UserBuilder struct {
+	value   User
+	nameSet bool
+	hooks   []func(*User) error
+}
+
+// SetName sets the Name of the User.
+func (builder *UserBuilder) SetName(name string) *UserBuilder {
+	builder.value.Name = name
+	builder.nameSet = true
+	return builder
+}
+
+// SetB sets the B of the User.
+func (builder *UserBuilder) SetB(b bool) *UserBuilder {
+	builder.value.B = b
+	return builder
+}
+
+// Validate adds a validation hook that is called by Build.
+func (builder *UserBuilder) Validate(hook func(*User) error) *UserBuilder {
+	builder.hooks = append(builder.hooks, hook)
+	return builder
+}
+
+// Build returns the User, it returns an error if a required field is not set.
+func (builder *UserBuilder) Build() (*User, error) {
+	var missing []string
+	if !builder.nameSet {
+		missing = append(missing, "Name")
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("missing required fields of User: %s", strings.Join(missing, ", "))
+	}
+	v := builder.value
+	for _, hook := range builder.hooks {
+		if err := hook(&v); err != nil {
+			return nil, err
+		}
+	}
+	return &v, nil
+}
+
+// NewUserBuilder creates a new UserBuilder.
+func NewUserBuilder() *UserBuilder {
+	return &UserBuilder{}
+}
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewFile("test", NewBuilder(tt.st, tt.options...)...).String(); got != tt.want {
+				t.Errorf("NewBuilder() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
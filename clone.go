@@ -0,0 +1,344 @@
+package code
+
+import (
+	"github.com/dave/jennifer/jen"
+)
+
+// Clone returns a copy of the import.
+func (i *Import) Clone() *Import {
+	if i == nil {
+		return nil
+	}
+	c := *i
+	return &c
+}
+
+// Clone returns a copy of the comment, comments are immutable so the same value is returned.
+func (c Comment) Clone() Comment {
+	return c
+}
+
+// Clone returns a deep copy of the type.
+//
+// RawType statements are copied token by token, nested jen groups inside the statement are shared.
+func (t Type) Clone() Type {
+	c := t
+	c.Import = t.Import.Clone()
+	c.Function = t.Function.Clone()
+	c.Struct = t.Struct.Clone()
+	c.RawType = cloneStatement(t.RawType)
+	if t.MapType != nil {
+		c.MapType = &struct {
+			Key   Type
+			Value Type
+		}{
+			Key:   t.MapType.Key.Clone(),
+			Value: t.MapType.Value.Clone(),
+		}
+	}
+	if t.ArrayType != nil {
+		at := t.ArrayType.Clone()
+		c.ArrayType = &at
+	}
+	if t.TypeArgs != nil {
+		c.TypeArgs = make([]Type, len(t.TypeArgs))
+		for i := range t.TypeArgs {
+			c.TypeArgs[i] = t.TypeArgs[i].Clone()
+		}
+	}
+	return c
+}
+
+// Clone returns a deep copy of the variable.
+func (v *Var) Clone() *Var {
+	if v == nil {
+		return nil
+	}
+	return &Var{
+		Name:  v.Name,
+		Type:  v.Type.Clone(),
+		Value: v.Value,
+		docs:  cloneComments(v.docs),
+	}
+}
+
+// Clone returns a deep copy of the constant.
+func (c *Const) Clone() *Const {
+	if c == nil {
+		return nil
+	}
+	cl := Const(*(*Var)(c).Clone())
+	return &cl
+}
+
+// Clone returns a deep copy of the parameter.
+func (p *Parameter) Clone() *Parameter {
+	if p == nil {
+		return nil
+	}
+	return &Parameter{
+		Name: p.Name,
+		Type: p.Type.Clone(),
+	}
+}
+
+// Clone returns a copy of the field tags.
+func (f *FieldTags) Clone() *FieldTags {
+	if f == nil {
+		return nil
+	}
+	if *f == nil {
+		return &FieldTags{}
+	}
+	tags := FieldTags{}
+	for k, v := range *f {
+		tags[k] = v
+	}
+	return &tags
+}
+
+// Clone returns a deep copy of the structure field.
+func (s *StructField) Clone() *StructField {
+	if s == nil {
+		return nil
+	}
+	return &StructField{
+		Parameter: *s.Parameter.Clone(),
+		Tags:      s.Tags.Clone(),
+		docs:      cloneComments(s.docs),
+	}
+}
+
+// Clone returns a deep copy of the structure including its attached methods.
+func (s *Struct) Clone() *Struct {
+	if s == nil {
+		return nil
+	}
+	return &Struct{
+		docs:       cloneComments(s.docs),
+		Name:       s.Name,
+		Fields:     cloneFields(s.Fields),
+		TypeParams: cloneParams(s.TypeParams),
+		methods:    cloneMethods(s.methods),
+	}
+}
+
+// Clone returns a deep copy of the type declaration including its attached methods.
+func (t *TypeDecl) Clone() *TypeDecl {
+	if t == nil {
+		return nil
+	}
+	return &TypeDecl{
+		docs:    cloneComments(t.docs),
+		Name:    t.Name,
+		Type:    t.Type.Clone(),
+		methods: cloneMethods(t.methods),
+	}
+}
+
+// Clone returns a deep copy of the struct type.
+func (s *StructType) Clone() *StructType {
+	if s == nil {
+		return nil
+	}
+	st := StructType(*(*Struct)(s).Clone())
+	return &st
+}
+
+// Clone returns a deep copy of the function.
+//
+// Body statements are copied token by token, nested jen groups inside the statements are shared.
+func (f *Function) Clone() *Function {
+	if f == nil {
+		return nil
+	}
+	return &Function{
+		Name:    f.Name,
+		Recv:    f.Recv.Clone(),
+		Params:  cloneParams(f.Params),
+		Results: cloneParams(f.Results),
+		Body:    cloneBody(f.Body),
+		docs:    cloneComments(f.docs),
+	}
+}
+
+// Clone returns a deep copy of the function type.
+func (m *FunctionType) Clone() *FunctionType {
+	if m == nil {
+		return nil
+	}
+	ft := FunctionType(*(*Function)(m).Clone())
+	return &ft
+}
+
+// Clone returns a deep copy of the interface method.
+func (m *InterfaceMethod) Clone() *InterfaceMethod {
+	if m == nil {
+		return nil
+	}
+	im := InterfaceMethod(*(*Function)(m).Clone())
+	return &im
+}
+
+// Clone returns a deep copy of the interface.
+func (i *Interface) Clone() *Interface {
+	if i == nil {
+		return nil
+	}
+	var methods []InterfaceMethod
+	if i.Methods != nil {
+		methods = make([]InterfaceMethod, len(i.Methods))
+		for inx := range i.Methods {
+			methods[inx] = *i.Methods[inx].Clone()
+		}
+	}
+	return &Interface{
+		Name:       i.Name,
+		Methods:    methods,
+		TypeParams: cloneParams(i.TypeParams),
+		docs:       cloneComments(i.docs),
+	}
+}
+
+// Clone returns a deep copy of the assertion.
+func (a *Assertion) Clone() *Assertion {
+	if a == nil {
+		return nil
+	}
+	return &Assertion{
+		Interface:  a.Interface.Clone(),
+		Type:       a.Type.Clone(),
+		TypeParams: cloneParams(a.TypeParams),
+		docs:       cloneComments(a.docs),
+	}
+}
+
+// Clone returns a copy of the raw code.
+//
+// The statement is copied token by token, nested jen groups inside the statement are shared.
+func (c *RawCode) Clone() *RawCode {
+	if c == nil {
+		return nil
+	}
+	return &RawCode{
+		code: cloneStatement(c.code),
+	}
+}
+
+// Clone returns a deep copy of the file, all code nodes known to this package are cloned,
+// other implementations of Code are shared between the two files.
+func (f *File) Clone() *File {
+	if f == nil {
+		return nil
+	}
+	var code []Code
+	if f.Code != nil {
+		code = make([]Code, len(f.Code))
+		for i, c := range f.Code {
+			code[i] = cloneCode(c)
+		}
+	}
+	cl := NewFile(f.pkg, code...)
+	if f.jenFile == nil {
+		cl.jenFile = nil
+	}
+	cl.SetImportAliases(f.aliases)
+	return cl
+}
+
+func cloneCode(c Code) Code {
+	switch v := c.(type) {
+	case Comment:
+		return v.Clone()
+	case *Comment:
+		cl := v.Clone()
+		return &cl
+	case Type:
+		return v.Clone()
+	case *Var:
+		return v.Clone()
+	case *Const:
+		return v.Clone()
+	case *StructField:
+		return v.Clone()
+	case *Struct:
+		return v.Clone()
+	case *TypeDecl:
+		return v.Clone()
+	case *Function:
+		return v.Clone()
+	case *InterfaceMethod:
+		return v.Clone()
+	case *Interface:
+		return v.Clone()
+	case *Assertion:
+		return v.Clone()
+	case *RawCode:
+		return v.Clone()
+	}
+	return c
+}
+
+func cloneComments(docs []Comment) []Comment {
+	if docs == nil {
+		return nil
+	}
+	return append([]Comment{}, docs...)
+}
+
+func cloneParams(params []Parameter) []Parameter {
+	if params == nil {
+		return nil
+	}
+	l := make([]Parameter, len(params))
+	for i := range params {
+		l[i] = *params[i].Clone()
+	}
+	return l
+}
+
+func cloneFields(fields []StructField) []StructField {
+	if fields == nil {
+		return nil
+	}
+	l := make([]StructField, len(fields))
+	for i := range fields {
+		l[i] = *fields[i].Clone()
+	}
+	return l
+}
+
+func cloneMethods(methods []*Function) []*Function {
+	if methods == nil {
+		return nil
+	}
+	l := make([]*Function, len(methods))
+	for i, m := range methods {
+		l[i] = m.Clone()
+	}
+	return l
+}
+
+func cloneStatement(s *jen.Statement) *jen.Statement {
+	if s == nil {
+		return nil
+	}
+	st := make(jen.Statement, len(*s))
+	copy(st, *s)
+	return &st
+}
+
+func cloneBody(body []jen.Code) []jen.Code {
+	if body == nil {
+		return nil
+	}
+	l := make([]jen.Code, len(body))
+	for i, c := range body {
+		if s, ok := c.(*jen.Statement); ok {
+			l[i] = cloneStatement(s)
+			continue
+		}
+		l[i] = c
+	}
+	return l
+}
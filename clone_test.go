@@ -0,0 +1,273 @@
+package code
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/dave/jennifer/jen"
+)
+
+func TestImport_Clone(t *testing.T) {
+	tests := []struct {
+		name string
+		i    *Import
+		want *Import
+	}{
+		{
+			name: "Should return nil for a nil import",
+			i:    nil,
+			want: nil,
+		},
+		{
+			name: "Should return a copy of the import",
+			i:    NewImportWithFilePath("code", "github.com/go-services/code", "/path/to/code"),
+			want: NewImportWithFilePath("code", "github.com/go-services/code", "/path/to/code"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.i.Clone()
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Import.Clone() = %v, want %v", got, tt.want)
+			}
+			if got != nil && got == tt.i {
+				t.Errorf("Import.Clone() returned the same pointer")
+			}
+		})
+	}
+}
+
+func TestType_Clone(t *testing.T) {
+	tests := []struct {
+		name   string
+		tp     Type
+		mutate func(tp *Type)
+	}{
+		{
+			name: "Should clone the type import",
+			tp:   NewType("Context", ImportTypeOption(*NewImport("ctx", "context"))),
+			mutate: func(tp *Type) {
+				tp.Import.Alias = "changed"
+			},
+		},
+		{
+			name: "Should clone the map type",
+			tp:   NewType("", MapTypeOption(NewType("string"), NewType("int"))),
+			mutate: func(tp *Type) {
+				tp.MapType.Key.Qualifier = "changed"
+			},
+		},
+		{
+			name: "Should clone the array type",
+			tp:   NewType("", ArrayTypeOption(NewType("string", PointerTypeOption()))),
+			mutate: func(tp *Type) {
+				tp.ArrayType.Pointer = false
+			},
+		},
+		{
+			name: "Should clone the function type",
+			tp: NewType("", FunctionTypeOption(NewFunctionType(
+				ParamsFunctionOption(*NewParameter("a", NewType("string"))),
+			))),
+			mutate: func(tp *Type) {
+				tp.Function.Params[0].Name = "changed"
+			},
+		},
+		{
+			name: "Should clone the struct type",
+			tp:   NewType("", StructTypeOption(*NewStructType(*NewStructField("A", NewType("string"))))),
+			mutate: func(tp *Type) {
+				tp.Struct.Fields[0].Name = "changed"
+			},
+		},
+		{
+			name: "Should clone the type arguments",
+			tp:   NewType("Repository", TypeArgsTypeOption(NewType("User"))),
+			mutate: func(tp *Type) {
+				tp.TypeArgs[0].Qualifier = "changed"
+			},
+		},
+		{
+			name: "Should clone the raw type",
+			tp:   NewRawType(jen.Map(jen.String()).Int()),
+			mutate: func(tp *Type) {
+				(*tp.RawType)[0] = jen.Id("changed")
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := tt.tp.String()
+			got := tt.tp.Clone()
+			if got.String() != want {
+				t.Errorf("Type.Clone() = %v, want %v", got.String(), want)
+			}
+			tt.mutate(&got)
+			if s := tt.tp.String(); s != want {
+				t.Errorf("Type.Clone() shares memory with the original, original = %v, want %v", s, want)
+			}
+		})
+	}
+}
+
+func TestVar_Clone(t *testing.T) {
+	v := NewVarWithValue("a", NewType("string"), "hello", "Some docs")
+	got := v.Clone()
+	if !reflect.DeepEqual(got, v) {
+		t.Errorf("Var.Clone() = %v, want %v", got, v)
+	}
+	got.AddDocs("More docs")
+	got.docs[0] = "Changed"
+	if !reflect.DeepEqual(v.docs, []Comment{"Some docs"}) {
+		t.Errorf("Var.Clone() shares docs with the original, docs = %v", v.docs)
+	}
+}
+
+func TestConst_Clone(t *testing.T) {
+	c := NewConst("a", NewType("string"), "hello", "Some docs")
+	got := c.Clone()
+	if !reflect.DeepEqual(got, c) {
+		t.Errorf("Const.Clone() = %v, want %v", got, c)
+	}
+	got.docs[0] = "Changed"
+	if !reflect.DeepEqual(c.docs, []Comment{"Some docs"}) {
+		t.Errorf("Const.Clone() shares docs with the original, docs = %v", c.docs)
+	}
+}
+
+func TestFieldTags_Clone(t *testing.T) {
+	tests := []struct {
+		name string
+		tags *FieldTags
+		want *FieldTags
+	}{
+		{
+			name: "Should return nil for nil tags",
+			tags: nil,
+			want: nil,
+		},
+		{
+			name: "Should return a copy of the tags",
+			tags: &FieldTags{"json": "a", "xml": "b"},
+			want: &FieldTags{"json": "a", "xml": "b"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.tags.Clone()
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FieldTags.Clone() = %v, want %v", got, tt.want)
+			}
+			if got != nil {
+				got.Set("json", "changed")
+				if (*tt.tags)["json"] != "a" {
+					t.Errorf("FieldTags.Clone() shares the map with the original")
+				}
+			}
+		})
+	}
+}
+
+func TestStruct_Clone(t *testing.T) {
+	s := NewStructWithFields(
+		"Test",
+		[]StructField{
+			*NewStructFieldWithTag("A", NewType("string"), NewFieldTags("json", "a"), "A docs"),
+			*NewStructField("B", NewType("Context", ImportTypeOption(*NewImport("ctx", "context")))),
+		},
+		"Struct docs",
+	)
+	want := s.String()
+	got := s.Clone()
+	if !reflect.DeepEqual(got, s) {
+		t.Errorf("Struct.Clone() = %v, want %v", got, s)
+	}
+	got.Name = "Changed"
+	got.docs[0] = "Changed"
+	got.Fields[0].Tags.Set("json", "changed")
+	got.Fields[0].docs[0] = "Changed"
+	got.Fields[1].Type.Import.Path = "changed"
+	got.Fields = append(got.Fields, *NewStructField("C", NewType("int")))
+	if s.String() != want {
+		t.Errorf("Struct.Clone() shares memory with the original, original = %v, want %v", s.String(), want)
+	}
+}
+
+func TestFunction_Clone(t *testing.T) {
+	f := NewFunction(
+		"Test",
+		RecvFunctionOption(NewParameter("t", NewType("T", PointerTypeOption()))),
+		ParamsFunctionOption(*NewParameter("a", NewType("string"))),
+		ResultsFunctionOption(*NewParameter("", NewType("error"))),
+		BodyFunctionOption(jen.Return(jen.Nil())),
+		DocsFunctionOption("Function docs"),
+	)
+	want := f.String()
+	got := f.Clone()
+	if !reflect.DeepEqual(got, f) {
+		t.Errorf("Function.Clone() = %v, want %v", got, f)
+	}
+	got.Recv.Name = "changed"
+	got.Params[0].Type.Qualifier = "int"
+	got.Results[0].Name = "err"
+	got.docs[0] = "Changed"
+	got.Body[0].(*jen.Statement).Add(jen.Id("changed"))
+	if f.String() != want {
+		t.Errorf("Function.Clone() shares memory with the original, original = %v, want %v", f.String(), want)
+	}
+}
+
+func TestInterface_Clone(t *testing.T) {
+	i := NewInterface(
+		"Test",
+		[]InterfaceMethod{
+			NewInterfaceMethod("Get", ParamsFunctionOption(*NewParameter("id", NewType("string")))),
+		},
+		"Interface docs",
+	)
+	i.TypeParams = []Parameter{*NewParameter("T", NewType("any"))}
+	want := i.String()
+	got := i.Clone()
+	if !reflect.DeepEqual(got, i) {
+		t.Errorf("Interface.Clone() = %v, want %v", got, i)
+	}
+	got.Methods[0].Params[0].Name = "changed"
+	got.TypeParams[0].Name = "K"
+	got.docs[0] = "Changed"
+	got.AddMethod(NewInterfaceMethod("Put"))
+	if i.String() != want {
+		t.Errorf("Interface.Clone() shares memory with the original, original = %v, want %v", i.String(), want)
+	}
+}
+
+func TestRawCode_Clone(t *testing.T) {
+	c := NewRawCode(jen.Var().Id("a").Op("=").Lit(1))
+	want := c.String()
+	got := c.Clone()
+	if got.String() != want {
+		t.Errorf("RawCode.Clone() = %v, want %v", got.String(), want)
+	}
+	got.Code().Op("+").Lit(2)
+	if c.String() != want {
+		t.Errorf("RawCode.Clone() shares memory with the original, original = %v, want %v", c.String(), want)
+	}
+}
+
+func TestFile_Clone(t *testing.T) {
+	st := NewStruct("Test")
+	f := NewFile("test", st, NewFunction("MyMethod", BodyFunctionOption(jen.Qual("fmt", "Println").Call())))
+	f.SetImportAliases([]ImportAlias{NewImportAlias("fmt_alias", "fmt")})
+	got := f.Clone()
+	if got.Code[0] == f.Code[0] {
+		t.Errorf("File.Clone() did not clone the code nodes")
+	}
+	got.Code[0].(*Struct).Name = "Changed"
+	got.Code = append(got.Code, NewStruct("Other"))
+	want := "package test\n\nimport fmt_alias \"fmt\"\n\ntype Changed struct{}\n\nfunc MyMethod() {\n\tfmt_alias.Println()\n}\n\ntype Other struct{}\n"
+	if s := got.String(); s != want {
+		t.Errorf("File.Clone().String() = %v, want %v", s, want)
+	}
+	if st.Name != "Test" || len(f.Code) != 2 {
+		t.Errorf("File.Clone() shares memory with the original")
+	}
+}
@@ -115,6 +115,10 @@ type Type struct {
 	// Qualifier specifies the qualifier, for simple types like `string` it is the only
 	// parameter set on the type.
 	Qualifier string
+
+	// TypeArgs are the type arguments of a generic type (e.x Repository[User]),
+	// they are only used together with the qualifier.
+	TypeArgs []Type
 }
 
 // Var represents a variable.
@@ -169,8 +173,42 @@ type Struct struct {
 
 	// Fields represents the structure fields.
 	Fields []StructField
+
+	// TypeParams are the type parameters of a generic structure, the type of the parameter is its constraint
+	// (e.x T any), they need to be set before methods are added so the receivers use them.
+	TypeParams []Parameter
+
+	// methods are the methods attached to the structure, they are rendered after the structure in a File.
+	methods []*Function
+}
+
+// TypeDecl represents a type declaration (e.x type MyType string).
+type TypeDecl struct {
+	// docs are the documentation comments of the type declaration.
+	docs []Comment
+
+	// Name is the name of the declared type.
+	Name string
+
+	// Type is the underlying type of the declared type.
+	Type Type
+
+	// methods are the methods attached to the type declaration, they are rendered after the type declaration in a File.
+	methods []*Function
+}
+
+// MethodSet is implemented by code nodes that can have methods attached (e.x Struct, TypeDecl).
+type MethodSet interface {
+	// Methods returns the methods attached to the type.
+	Methods() []*Function
 }
 
+// MethodOptions is used when you call AddMethod, it is a handy way to allow multiple configurations
+// for the method receiver.
+//
+// By default the receiver is a pointer to the type named after the first letter of the type name.
+type MethodOptions func(recv *Parameter)
+
 // FunctionOptions is used when you call NewFunction, it is a handy way to allow multiple configurations
 // for a function.
 //
@@ -209,10 +247,31 @@ type Interface struct {
 	// Methods are the interface methods, the interface can also have no methods.
 	Methods []InterfaceMethod
 
+	// TypeParams are the type parameters of a generic interface, the type of the parameter is its constraint (e.x T any).
+	TypeParams []Parameter
+
 	// docs are the interface documentation coments.
 	docs []Comment
 }
 
+// Assertion represents a compile-time interface assertion (e.x var _ Iface = (*Impl)(nil)).
+type Assertion struct {
+	// Interface is the type of the interface (e.x io.Writer).
+	Interface Type
+
+	// Type is the implementing type, pointer types are asserted with a nil pointer (e.x (*Impl)(nil))
+	// and other types with an empty composite literal (e.x Impl{}).
+	Type Type
+
+	// TypeParams are the type parameters used in the type arguments of the interface and the type,
+	// if they are set the assertion is rendered in a generic function
+	// (e.x func _[T any]() { var _ Repository[T] = (*Impl[T])(nil) }).
+	TypeParams []Parameter
+
+	// docs are the documentation comments of the assertion.
+	docs []Comment
+}
+
 // RawCode represents raw lines of code.
 type RawCode struct {
 	code *jen.Statement
@@ -291,6 +350,13 @@ func MapTypeOption(key Type, value Type) TypeOptions {
 	}
 }
 
+// TypeArgsTypeOption sets the type arguments of a generic type (e.x Repository[User]).
+func TypeArgsTypeOption(args ...Type) TypeOptions {
+	return func(t *Type) {
+		t.TypeArgs = args
+	}
+}
+
 // StructTypeOption sets the map type.
 func StructTypeOption(st StructType) TypeOptions {
 	return func(t *Type) {
@@ -409,6 +475,30 @@ func NewStructWithFields(name string, fields []StructField, docs ...Comment) *St
 	return st
 }
 
+// NewTypeDecl creates a new type declaration with the given name and underlying type,
+// there is also an optional list of documentation comments that you can add to the type declaration
+func NewTypeDecl(name string, tp Type, docs ...Comment) *TypeDecl {
+	return &TypeDecl{
+		Name: name,
+		Type: tp,
+		docs: docs,
+	}
+}
+
+// ValueReceiverMethodOption makes the method use a value receiver (e.x func (m MyStruct) name() {}).
+func ValueReceiverMethodOption() MethodOptions {
+	return func(recv *Parameter) {
+		recv.Type.Pointer = false
+	}
+}
+
+// ReceiverNameMethodOption sets the name of the method receiver.
+func ReceiverNameMethodOption(name string) MethodOptions {
+	return func(recv *Parameter) {
+		recv.Name = name
+	}
+}
+
 // ParamsFunctionOption adds given parameters to the function.
 func ParamsFunctionOption(params ...Parameter) FunctionOptions {
 	return func(f *Function) {
@@ -487,6 +577,27 @@ func NewInterface(name string, methods []InterfaceMethod, docs ...Comment) *Inte
 	}
 }
 
+// NewAssertion creates a new compile-time assertion that the type implements the interface type,
+// there is also an optional list of documentation comments that you can add to the assertion
+func NewAssertion(iface Type, tp Type, docs ...Comment) *Assertion {
+	return &Assertion{
+		Interface: iface,
+		Type:      tp,
+		docs:      docs,
+	}
+}
+
+// NewInterfaceAssertion creates a new compile-time assertion that the type implements the interface,
+// there is also an optional list of documentation comments that you can add to the assertion.
+//
+// If the interface is generic the interface is instantiated with its type parameters,
+// the type needs to use the same type parameters (e.x Impl[T]).
+func NewInterfaceAssertion(iface *Interface, tp Type, docs ...Comment) *Assertion {
+	a := NewAssertion(NewType(iface.Name, TypeArgsTypeOption(typeParamArgs(iface.TypeParams)...)), tp, docs...)
+	a.TypeParams = cloneParams(iface.TypeParams)
+	return a
+}
+
 func NewRawCode(code *jen.Statement) *RawCode {
 	return &RawCode{
 		code: code,
@@ -569,19 +680,39 @@ func (t Type) Code() *jen.Statement {
 	}
 	if t.Import != nil {
 		code.Qual(t.Import.Path, t.Qualifier)
-		return code
+	} else {
+		code.Id(t.Qualifier)
 	}
-	return code.Id(t.Qualifier)
+	if len(t.TypeArgs) > 0 {
+		code.Types(typeList(t.TypeArgs)...)
+	}
+	return code
 }
 
-// ImportAliases returns the import aliases of the type.
+// ImportAliases returns the import aliases of the type,
+// including the aliases of array, map, function and struct types.
 func (t Type) ImportAliases() []ImportAlias {
+	var aliases []ImportAlias
 	if t.Import != nil && t.Import.Alias != "" {
-		return []ImportAlias{
-			NewImportAlias(t.Import.Alias, t.Import.Path),
-		}
+		aliases = append(aliases, NewImportAlias(t.Import.Alias, t.Import.Path))
 	}
-	return nil
+	if t.ArrayType != nil {
+		aliases = append(aliases, t.ArrayType.ImportAliases()...)
+	}
+	if t.MapType != nil {
+		aliases = append(aliases, t.MapType.Key.ImportAliases()...)
+		aliases = append(aliases, t.MapType.Value.ImportAliases()...)
+	}
+	if t.Function != nil {
+		aliases = append(aliases, (*Function)(t.Function).ImportAliases()...)
+	}
+	if t.Struct != nil {
+		aliases = append(aliases, (*Struct)(t.Struct).ImportAliases()...)
+	}
+	for _, a := range t.TypeArgs {
+		aliases = append(aliases, a.ImportAliases()...)
+	}
+	return aliases
 }
 
 // String returns the go code string of the type,
@@ -883,7 +1014,11 @@ func (s *StructField) ImportAliases() []ImportAlias {
 func (s *Struct) Code() *jen.Statement {
 	code := &jen.Statement{}
 	addDocsCode(code, s.docs)
-	return code.Type().Id(s.Name).Struct(fieldList(s.Fields)...)
+	code.Type().Id(s.Name)
+	if len(s.TypeParams) > 0 {
+		code.Types(paramsList(s.TypeParams)...)
+	}
+	return code.Struct(fieldList(s.Fields)...)
 }
 
 // String returns the go code string of the structure.
@@ -907,9 +1042,67 @@ func (s *Struct) ImportAliases() []ImportAlias {
 	for _, p := range s.Fields {
 		aliases = append(aliases, p.ImportAliases()...)
 	}
+	for _, p := range s.TypeParams {
+		aliases = append(aliases, p.Type.ImportAliases()...)
+	}
 	return aliases
 }
 
+// AddMethod attaches the function as a method of the structure, the receiver of the function is set to
+// the structure and the method is rendered after the structure in a File.
+//
+// If the function already has a receiver name it is kept, options can be used to change the receiver.
+func (s *Struct) AddMethod(fn *Function, options ...MethodOptions) {
+	setReceiver(s.Name, s.TypeParams, fn, options)
+	s.methods = append(s.methods, fn)
+}
+
+// Methods returns the methods attached to the structure.
+func (s *Struct) Methods() []*Function {
+	return s.methods
+}
+
+// Code returns the jen representation of the type declaration.
+func (t *TypeDecl) Code() *jen.Statement {
+	code := &jen.Statement{}
+	addDocsCode(code, t.docs)
+	return code.Type().Id(t.Name).Add(t.Type.Code())
+}
+
+// String returns the go code string of the type declaration.
+func (t *TypeDecl) String() string {
+	return codeString(t)
+}
+
+// Docs returns the docs comments of the type declaration.
+func (t *TypeDecl) Docs() []Comment {
+	return t.docs
+}
+
+// AddDocs adds a list of documentation strings to the type declaration.
+func (t *TypeDecl) AddDocs(docs ...Comment) {
+	t.docs = append(t.docs, docs...)
+}
+
+// ImportAliases returns the import aliases of the type declaration.
+func (t *TypeDecl) ImportAliases() []ImportAlias {
+	return t.Type.ImportAliases()
+}
+
+// AddMethod attaches the function as a method of the type declaration, the receiver of the function is set to
+// the declared type and the method is rendered after the type declaration in a File.
+//
+// If the function already has a receiver name it is kept, options can be used to change the receiver.
+func (t *TypeDecl) AddMethod(fn *Function, options ...MethodOptions) {
+	setReceiver(t.Name, nil, fn, options)
+	t.methods = append(t.methods, fn)
+}
+
+// Methods returns the methods attached to the type declaration.
+func (t *TypeDecl) Methods() []*Function {
+	return t.methods
+}
+
 // Code returns the jen representation of the interface method.
 func (m *InterfaceMethod) Code() *jen.Statement {
 	code := &jen.Statement{}
@@ -963,7 +1156,11 @@ func (m *InterfaceMethod) ImportAliases() []ImportAlias {
 func (i *Interface) Code() *jen.Statement {
 	code := &jen.Statement{}
 	addDocsCode(code, i.docs)
-	code.Type().Id(i.Name).Interface(
+	code.Type().Id(i.Name)
+	if len(i.TypeParams) > 0 {
+		code.Types(paramsList(i.TypeParams)...)
+	}
+	code.Interface(
 		func() []jen.Code {
 			var c []jen.Code
 			for _, m := range i.Methods {
@@ -1001,6 +1198,49 @@ func (i *Interface) ImportAliases() []ImportAlias {
 	for _, m := range i.Methods {
 		aliases = append(aliases, m.ImportAliases()...)
 	}
+	for _, p := range i.TypeParams {
+		aliases = append(aliases, p.Type.ImportAliases()...)
+	}
+	return aliases
+}
+
+// Code returns the jen representation of the assertion.
+func (a *Assertion) Code() *jen.Statement {
+	code := &jen.Statement{}
+	addDocsCode(code, a.docs)
+	assertion := jen.Var().Id("_").Add(a.Interface.Code()).Op("=")
+	if a.Type.Pointer {
+		assertion.Parens(a.Type.Code()).Call(jen.Nil())
+	} else {
+		assertion.Add(a.Type.Code()).Values()
+	}
+	if len(a.TypeParams) > 0 {
+		return code.Func().Id("_").Types(paramsList(a.TypeParams)...).Params().Block(assertion)
+	}
+	return code.Add(assertion)
+}
+
+// String returns the go code string of the assertion.
+func (a *Assertion) String() string {
+	return codeString(a)
+}
+
+// Docs returns the docs comments of the assertion.
+func (a *Assertion) Docs() []Comment {
+	return a.docs
+}
+
+// AddDocs adds a list of documentation strings to the assertion.
+func (a *Assertion) AddDocs(docs ...Comment) {
+	a.docs = append(a.docs, docs...)
+}
+
+// ImportAliases returns the import aliases of the interface, the implementing type and the type parameters.
+func (a *Assertion) ImportAliases() []ImportAlias {
+	aliases := append(a.Interface.ImportAliases(), a.Type.ImportAliases()...)
+	for _, p := range a.TypeParams {
+		aliases = append(aliases, p.Type.ImportAliases()...)
+	}
 	return aliases
 }
 
@@ -1014,6 +1254,28 @@ func (f *FieldTags) Set(key, value string) {
 	(*f)[key] = value
 }
 
+func setReceiver(typeName string, typeParams []Parameter, fn *Function, options []MethodOptions) {
+	recv := &Parameter{
+		Name: receiverName(typeName),
+		Type: NewType(typeName, PointerTypeOption(), TypeArgsTypeOption(typeParamArgs(typeParams)...)),
+	}
+	if fn.Recv != nil && fn.Recv.Name != "" {
+		recv.Name = fn.Recv.Name
+	}
+	for _, o := range options {
+		o(recv)
+	}
+	fn.Recv = recv
+}
+
+// receiverName returns the default receiver name for the type which is the first letter of the type name lowercased.
+func receiverName(typeName string) string {
+	if typeName == "" {
+		return ""
+	}
+	return strings.ToLower(typeName[:1])
+}
+
 func fieldList(fields []StructField) (f []jen.Code) {
 	for _, p := range fields {
 		f = append(f, p.Code())
@@ -1021,6 +1283,22 @@ func fieldList(fields []StructField) (f []jen.Code) {
 	return
 }
 
+func typeList(types []Type) (l []jen.Code) {
+	for _, t := range types {
+		l = append(l, t.Code())
+	}
+	return
+}
+
+// typeParamArgs returns the type parameters as type arguments (e.x [T any, K comparable] => [T, K]).
+func typeParamArgs(typeParams []Parameter) []Type {
+	var args []Type
+	for _, p := range typeParams {
+		args = append(args, NewType(p.Name))
+	}
+	return args
+}
+
 func codeString(c Code) string {
 	return c.Code().GoString()
 }
@@ -262,6 +262,43 @@ func TestArrayTypeOption(t *testing.T) {
 	}
 }
 
+func TestTypeArgsTypeOption(t *testing.T) {
+	type args struct {
+		tp       Type
+		typeArgs []Type
+	}
+	tests := []struct {
+		name string
+		args args
+		want Type
+	}{
+		{
+			name: "Should set the type arguments",
+			args: args{
+				tp:       NewType("Repository"),
+				typeArgs: []Type{NewType("User"), NewType("string")},
+			},
+			want: Type{
+				Qualifier: "Repository",
+				TypeArgs:  []Type{NewType("User"), NewType("string")},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := TypeArgsTypeOption(tt.args.typeArgs...)
+			got(&tt.args.tp)
+			if !reflect.DeepEqual(tt.args.tp, tt.want) {
+				t.Errorf("Type = %v, want %v", tt.args.tp, tt.want)
+			}
+			want := "Repository[User, string]"
+			if got := tt.args.tp.String(); got != want {
+				t.Errorf("Type.String() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
 func TestMapTypeOption(t *testing.T) {
 	type args struct {
 		tp    Type
@@ -3389,9 +3426,10 @@ func TestInterface_Code(t *testing.T) {
 
 func TestInterface_String(t *testing.T) {
 	type fields struct {
-		docs    []Comment
-		Name    string
-		Methods []InterfaceMethod
+		docs       []Comment
+		Name       string
+		Methods    []InterfaceMethod
+		TypeParams []Parameter
 	}
 	tests := []struct {
 		name   string
@@ -3429,13 +3467,25 @@ func TestInterface_String(t *testing.T) {
 			},
 			want: "// Hello\n// Hi\ntype MyInterface interface {\n\tTest()\n\tTest2()\n}",
 		},
+		{
+			name: "Should return the correct jen representation of the generic interface",
+			fields: fields{
+				Name: "MyInterface",
+				Methods: []InterfaceMethod{
+					NewInterfaceMethod("Get", ResultsFunctionOption(*NewParameter("", NewType("T")))),
+				},
+				TypeParams: []Parameter{*NewParameter("T", NewType("any")), *NewParameter("K", NewType("comparable"))},
+			},
+			want: "type MyInterface[T any, K comparable] interface {\n\tGet() T\n}",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			i := &Interface{
-				docs:    tt.fields.docs,
-				Name:    tt.fields.Name,
-				Methods: tt.fields.Methods,
+				docs:       tt.fields.docs,
+				Name:       tt.fields.Name,
+				Methods:    tt.fields.Methods,
+				TypeParams: tt.fields.TypeParams,
 			}
 			if got := i.String(); got != tt.want {
 				t.Errorf("Interface.String() = %v, want %v", got, tt.want)
@@ -4295,3 +4345,269 @@ func TestStructTypeOption(t *testing.T) {
 		})
 	}
 }
+
+func TestNewTypeDecl(t *testing.T) {
+	type args struct {
+		name string
+		tp   Type
+		docs []Comment
+	}
+	tests := []struct {
+		name string
+		args args
+		want *TypeDecl
+	}{
+		{
+			name: "Should create a new type declaration",
+			args: args{
+				name: "Celsius",
+				tp:   NewType("float64"),
+				docs: []Comment{"Celsius is a temperature"},
+			},
+			want: &TypeDecl{
+				Name: "Celsius",
+				Type: NewType("float64"),
+				docs: []Comment{"Celsius is a temperature"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewTypeDecl(tt.args.name, tt.args.tp, tt.args.docs...); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewTypeDecl() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTypeDecl_String(t *testing.T) {
+	tests := []struct {
+		name string
+		td   *TypeDecl
+		want string
+	}{
+		{
+			name: "Should return the go code of the type declaration",
+			td:   NewTypeDecl("Celsius", NewType("float64")),
+			want: "type Celsius float64",
+		},
+		{
+			name: "Should return the go code of the type declaration with docs",
+			td:   NewTypeDecl("Handler", NewType("Handler", ImportTypeOption(*NewImport("", "net/http"))), "Handler docs"),
+			want: "// Handler docs\ntype Handler http.Handler",
+		},
+		{
+			name: "Should not render the attached methods",
+			td: func() *TypeDecl {
+				td := NewTypeDecl("Celsius", NewType("float64"))
+				td.AddMethod(NewFunction("String"))
+				return td
+			}(),
+			want: "type Celsius float64",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.td.String(); got != tt.want {
+				t.Errorf("TypeDecl.String() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTypeDecl_ImportAliases(t *testing.T) {
+	td := NewTypeDecl("Handler", NewType("Handler", ImportTypeOption(*NewImport("h", "net/http"))))
+	want := []ImportAlias{NewImportAlias("h", "net/http")}
+	if got := td.ImportAliases(); !reflect.DeepEqual(got, want) {
+		t.Errorf("TypeDecl.ImportAliases() = %v, want %v", got, want)
+	}
+}
+
+func TestStruct_AddMethod(t *testing.T) {
+	type args struct {
+		fn      *Function
+		options []MethodOptions
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "Should add a method with a default pointer receiver",
+			args: args{
+				fn: NewFunction("Name", ResultsFunctionOption(*NewParameter("", NewType("string")))),
+			},
+			want: "func (m *MyStruct) Name() string {}",
+		},
+		{
+			name: "Should add a method with a value receiver",
+			args: args{
+				fn:      NewFunction("Name"),
+				options: []MethodOptions{ValueReceiverMethodOption()},
+			},
+			want: "func (m MyStruct) Name() {}",
+		},
+		{
+			name: "Should add a method with the given receiver name",
+			args: args{
+				fn:      NewFunction("Name"),
+				options: []MethodOptions{ReceiverNameMethodOption("self")},
+			},
+			want: "func (self *MyStruct) Name() {}",
+		},
+		{
+			name: "Should keep the existing receiver name and override the receiver type",
+			args: args{
+				fn: NewFunction("Name", RecvFunctionOption(NewParameter("ms", NewType("Other")))),
+			},
+			want: "func (ms *MyStruct) Name() {}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewStruct("MyStruct")
+			s.AddMethod(tt.args.fn, tt.args.options...)
+			if got := s.Methods(); len(got) != 1 || got[0] != tt.args.fn {
+				t.Errorf("Struct.Methods() = %v, want [%v]", got, tt.args.fn)
+			}
+			if got := tt.args.fn.String(); got != tt.want {
+				t.Errorf("Struct.AddMethod() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStruct_AddMethod_TypeParams(t *testing.T) {
+	s := NewStructWithFields("List", []StructField{*NewStructField("items", NewType("", ArrayTypeOption(NewType("T"))))})
+	s.TypeParams = []Parameter{*NewParameter("T", NewType("any"))}
+	fn := NewFunction("Len", ResultsFunctionOption(*NewParameter("", NewType("int"))))
+	s.AddMethod(fn)
+	want := "package test\n\ntype List[T any] struct {\n\titems []T\n}\n\nfunc (l *List[T]) Len() int {}\n"
+	if got := NewFile("test", s).String(); got != want {
+		t.Errorf("Struct.AddMethod() = %v, want %v", got, want)
+	}
+}
+
+func TestTypeDecl_AddMethod(t *testing.T) {
+	td := NewTypeDecl("Celsius", NewType("float64"))
+	fn := NewFunction("String", ResultsFunctionOption(*NewParameter("", NewType("string"))))
+	td.AddMethod(fn, ValueReceiverMethodOption())
+	if got := td.Methods(); len(got) != 1 || got[0] != fn {
+		t.Errorf("TypeDecl.Methods() = %v, want [%v]", got, fn)
+	}
+	want := "func (c Celsius) String() string {}"
+	if got := fn.String(); got != want {
+		t.Errorf("TypeDecl.AddMethod() = %v, want %v", got, want)
+	}
+}
+
+func TestType_ImportAliases(t *testing.T) {
+	ctx := NewType("Context", ImportTypeOption(*NewImport("ctx", "context")))
+	tests := []struct {
+		name string
+		tp   Type
+		want []ImportAlias
+	}{
+		{
+			name: "Should return nil if there are no aliases",
+			tp:   NewType("Context", ImportTypeOption(*NewImport("", "context"))),
+		},
+		{
+			name: "Should return the import alias",
+			tp:   ctx,
+			want: []ImportAlias{NewImportAlias("ctx", "context")},
+		},
+		{
+			name: "Should return the aliases of array and map types",
+			tp: NewType("", MapTypeOption(
+				NewType("Time", ImportTypeOption(*NewImport("tm", "time"))),
+				NewType("", ArrayTypeOption(ctx)),
+			)),
+			want: []ImportAlias{NewImportAlias("tm", "time"), NewImportAlias("ctx", "context")},
+		},
+		{
+			name: "Should return the aliases of function and struct types",
+			tp: NewType("", FunctionTypeOption(NewFunctionType(
+				ParamsFunctionOption(*NewParameter("", NewType("", StructTypeOption(*NewStructType(*NewStructField("C", ctx)))))),
+			))),
+			want: []ImportAlias{NewImportAlias("ctx", "context")},
+		},
+		{
+			name: "Should return the aliases of type arguments",
+			tp:   NewType("Repository", TypeArgsTypeOption(ctx)),
+			want: []ImportAlias{NewImportAlias("ctx", "context")},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.tp.ImportAliases(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Type.ImportAliases() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAssertion_String(t *testing.T) {
+	writer := NewType("Writer", ImportTypeOption(*NewImport("", "io")))
+	tests := []struct {
+		name string
+		a    *Assertion
+		want string
+	}{
+		{
+			name: "Should assert a pointer type",
+			a:    NewInterfaceAssertion(NewInterface("Service", nil), NewType("Impl", PointerTypeOption())),
+			want: "var _ Service = (*Impl)(nil)",
+		},
+		{
+			name: "Should assert a value type",
+			a:    NewInterfaceAssertion(NewInterface("Service", nil), NewType("Impl")),
+			want: "var _ Service = Impl{}",
+		},
+		{
+			name: "Should assert an imported interface type with docs",
+			a:    NewAssertion(writer, NewType("Buffer", ImportTypeOption(*NewImport("", "bytes")), PointerTypeOption()), "Buffer is a writer"),
+			want: "// Buffer is a writer\nvar _ io.Writer = (*bytes.Buffer)(nil)",
+		},
+		{
+			name: "Should assert a generic type in a generic function",
+			a: NewInterfaceAssertion(
+				&Interface{Name: "Service", TypeParams: []Parameter{*NewParameter("T", NewType("any"))}},
+				NewType("Impl", PointerTypeOption(), TypeArgsTypeOption(NewType("T"))),
+			),
+			want: "func _[T any]() {\n\tvar _ Service[T] = (*Impl[T])(nil)\n}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.a.String(); got != tt.want {
+				t.Errorf("Assertion.String() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAssertion_ImportAliases(t *testing.T) {
+	a := NewAssertion(
+		NewType("Writer", ImportTypeOption(*NewImport("stdio", "io"))),
+		NewType("Buffer", ImportTypeOption(*NewImport("buf", "bytes")), PointerTypeOption()),
+	)
+	want := []ImportAlias{NewImportAlias("stdio", "io"), NewImportAlias("buf", "bytes")}
+	if got := a.ImportAliases(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Assertion.ImportAliases() = %v, want %v", got, want)
+	}
+	f := NewFile("test", a)
+	wantFile := "package test\n\nimport (\n\tbuf \"bytes\"\n\tstdio \"io\"\n)\n\nvar _ stdio.Writer = (*buf.Buffer)(nil)\n"
+	if got := f.String(); got != wantFile {
+		t.Errorf("File.String() = %v, want %v", got, wantFile)
+	}
+}
+
+func TestAssertion_AddDocs(t *testing.T) {
+	a := NewInterfaceAssertion(NewInterface("Service", nil), NewType("Impl"), "First")
+	a.AddDocs("Second")
+	if want := []Comment{"First", "Second"}; !reflect.DeepEqual(a.Docs(), want) {
+		t.Errorf("Assertion.Docs() = %v, want %v", a.Docs(), want)
+	}
+}
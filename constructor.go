@@ -0,0 +1,102 @@
+package code
+
+import (
+	"strings"
+
+	"github.com/dave/jennifer/jen"
+)
+
+// ConstructorOptions is used when you call NewConstructor, it is a handy way to allow multiple configurations
+// for the generated constructor.
+type ConstructorOptions func(o *constructorOptions)
+
+type constructorOptions struct {
+	exported bool
+	tagKey   string
+	tagValue string
+}
+
+// ExportedConstructorOption only uses the exported fields as constructor parameters.
+func ExportedConstructorOption() ConstructorOptions {
+	return func(o *constructorOptions) {
+		o.exported = true
+	}
+}
+
+// TagConstructorOption only uses the fields that have the tag option as constructor parameters
+// (e.x TagConstructorOption("new", "required") uses the fields tagged with `new:"required"`).
+func TagConstructorOption(key, option string) ConstructorOptions {
+	return func(o *constructorOptions) {
+		o.tagKey = key
+		o.tagValue = option
+	}
+}
+
+// NewConstructor creates the New{Name} constructor of the structure.
+//
+// By default every field is a constructor parameter, the parameters are named after the fields in lower camel case
+// (e.x HTTPClient => httpClient) and the constructor returns a pointer to the structure.
+// The constructor docs describe the parameters with the docs of their fields.
+func NewConstructor(st *Struct, options ...ConstructorOptions) *Function {
+	opts := &constructorOptions{}
+	for _, o := range options {
+		o(opts)
+	}
+	values := jen.Dict{}
+	var params []Parameter
+	docs := []Comment{Comment("New" + exportedName(st.Name) + " creates a new " + st.Name + ".")}
+	var paramDocs []Comment
+	for _, f := range st.Fields {
+		name := fieldName(f)
+		if !opts.uses(f) {
+			continue
+		}
+		param := paramName(name)
+		values[jen.Id(name)] = jen.Id(param)
+		params = append(params, *NewParameter(param, f.Type.Clone()))
+		if doc := fieldDoc(f, param); doc != "" {
+			paramDocs = append(paramDocs, doc)
+		}
+	}
+	if len(paramDocs) > 0 {
+		docs = append(docs, "")
+		docs = append(docs, paramDocs...)
+	}
+	return NewFunction(
+		"New"+exportedName(st.Name),
+		ParamsFunctionOption(params...),
+		ResultsFunctionOption(*NewParameter("", NewType(st.Name, PointerTypeOption()))),
+		BodyFunctionOption(jen.Return(jen.Op("&").Id(st.Name).Values(values))),
+		DocsFunctionOption(docs...),
+	)
+}
+
+func (o *constructorOptions) uses(f StructField) bool {
+	name := fieldName(f)
+	if o.exported && !isExported(name) {
+		return false
+	}
+	if o.tagKey != "" && !hasTagOption(f, o.tagKey, o.tagValue) {
+		return false
+	}
+	return true
+}
+
+// fieldDoc joins the field docs in one line and replaces the field name at the start with the name
+// (e.x "Addr is the listen address." => "addr is the listen address.").
+func fieldDoc(f StructField, name string) Comment {
+	var lines []string
+	for _, d := range f.docs {
+		if s := strings.TrimSpace(string(d)); s != "" {
+			lines = append(lines, s)
+		}
+	}
+	doc := strings.Join(lines, " ")
+	if doc == "" {
+		return ""
+	}
+	if field := fieldName(f); strings.HasPrefix(doc, field+" ") {
+		return Comment(name + doc[len(field):])
+	}
+	return Comment(name + ": " + doc)
+}
@@ -0,0 +1,121 @@
+package code
+
+import (
+	"testing"
+)
+
+func TestNewConstructor(t *testing.T) {
+	st := NewStructWithFields("Server", []StructField{
+		*NewStructFieldWithTag("Addr", NewType("string"), NewFieldTags("new", "required"), "Addr is the listen address."),
+		*NewStructField("HTTPClient", NewType("Client", ImportTypeOption(*NewImport("", "net/http")), PointerTypeOption()), "the client used for requests."),
+		*NewStructField("Type", NewType("string")),
+		*NewStructFieldWithTag("logger", NewType("Logger", ImportTypeOption(*NewImport("", "log")), PointerTypeOption()), NewFieldTags("new", "required")),
+	})
+	tests := []struct {
+		name    string
+		options []ConstructorOptions
+		want    string
+	}{
+		{
+			name: "Should create a constructor with all the fields",
+			want: `package test
+
+import (
+	"log"
+	"net/http"
+)
+
+// NewServer creates a new Server.
+//
+// addr is the listen address.
+// httpClient: the client used for requests.
+func NewServer(addr string, httpClient *http.Client, type_ string, logger *log.Logger) *Server {
+	return &Server{
+		Addr:       addr,
+		HTTPClient: httpClient,
+		Type:       type_,
+		logger:     logger,
+	}
+}
+`,
+		},
+		{
+			name:    "Should create a constructor with the exported fields",
+			options: []ConstructorOptions{ExportedConstructorOption()},
+			want: `package test
+
+import "net/http"
+
+// NewServer creates a new Server.
+//
+// addr is the listen address.
+// httpClient: the client used for requests.
+func NewServer(addr string, httpClient *http.Client, type_ string) *Server {
+	return &Server{
+		Addr:       addr,
+		HTTPClient: httpClient,
+		Type:       type_,
+	}
+}
+`,
+		},
+		{
+			name:    "Should create a constructor with the tagged fields",
+			options: []ConstructorOptions{TagConstructorOption("new", "required")},
+			want: `package test
+
+import "log"
+
+// NewServer creates a new Server.
+//
+// addr is the listen address.
+func NewServer(addr string, logger *log.Logger) *Server {
+	return &Server{
+		Addr:   addr,
+		logger: logger,
+	}
+}
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewFile("test", NewConstructor(st, tt.options...)).String(); got != tt.want {
+				t.Errorf("NewConstructor() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewConstructor_NoParams(t *testing.T) {
+	want := `package test
+
+// NewEmpty creates a new Empty.
+func NewEmpty() *Empty {
+	return &Empty{}
+}
+`
+	if got := NewFile("test", NewConstructor(NewStruct("Empty"))).String(); got != want {
+		t.Errorf("NewConstructor() = %v, want %v", got, want)
+	}
+}
+
+func Test_paramName(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "Addr", want: "addr"},
+		{name: "ID", want: "id"},
+		{name: "HTTPClient", want: "httpClient"},
+		{name: "Type", want: "type_"},
+		{name: "userID", want: "userID"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := paramName(tt.name); got != tt.want {
+				t.Errorf("paramName() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
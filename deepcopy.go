@@ -0,0 +1,173 @@
+package code
+
+import (
+	"strconv"
+
+	"github.com/dave/jennifer/jen"
+)
+
+// DeepCopyOptions is used when you call AddDeepCopy, it is a handy way to allow multiple configurations
+// for the generated deep copy methods.
+type DeepCopyOptions func(o *deepCopyOptions)
+
+type deepCopyOptions struct {
+	types []string
+}
+
+// TypesDeepCopyOption sets the names of the package types that have generated DeepCopy and DeepCopyInto methods,
+// fields of those types are copied with their methods instead of being assigned.
+func TypesDeepCopyOption(names ...string) DeepCopyOptions {
+	return func(o *deepCopyOptions) {
+		o.types = append(o.types, names...)
+	}
+}
+
+// AddDeepCopy adds the DeepCopy and DeepCopyInto methods to the structure and returns the added methods.
+//
+// The generated code follows the structure of the field types, pointers, slices and maps are copied
+// recursively and fields of the structure type or of a type set with TypesDeepCopyOption are copied with their
+// DeepCopyInto method. The other fields (e.x interfaces, functions and imported types) are assigned.
+func AddDeepCopy(st *Struct, options ...DeepCopyOptions) []*Function {
+	opts := &deepCopyOptions{
+		types: []string{st.Name},
+	}
+	for _, o := range options {
+		o(opts)
+	}
+	body := []jen.Code{jen.Op("*").Id("out").Op("=").Op("*").Id("in")}
+	for _, f := range st.Fields {
+		name := fieldName(f)
+		in := func() *jen.Statement { return jen.Id("in").Dot(name) }
+		out := func() *jen.Statement { return jen.Id("out").Dot(name) }
+		body = append(body, opts.copy(in, out, f.Type, 0)...)
+	}
+	methods := []*Function{
+		NewFunction(
+			"DeepCopyInto",
+			ParamsFunctionOption(*NewParameter("out", NewType(st.Name, PointerTypeOption()))),
+			BodyFunctionOption(body...),
+			DocsFunctionOption(Comment("DeepCopyInto copies the "+st.Name+" into out, in must not be nil.")),
+		),
+		NewFunction(
+			"DeepCopy",
+			ResultsFunctionOption(*NewParameter("", NewType(st.Name, PointerTypeOption()))),
+			BodyFunctionOption(
+				jen.If(jen.Id("in").Op("==").Nil()).Block(jen.Return(jen.Nil())),
+				jen.Id("out").Op(":=").New(jen.Id(st.Name)),
+				jen.Id("in").Dot("DeepCopyInto").Call(jen.Id("out")),
+				jen.Return(jen.Id("out")),
+			),
+			DocsFunctionOption(Comment("DeepCopy returns a deep copy of the "+st.Name+".")),
+		),
+	}
+	for _, m := range methods {
+		st.AddMethod(m, ReceiverNameMethodOption("in"))
+	}
+	return methods
+}
+
+// copy returns the code that deep copies in to out, out is already a shallow copy of in.
+// The depth is used to name the loop variables of nested slices and maps.
+func (o *deepCopyOptions) copy(in, out func() *jen.Statement, tp Type, depth int) []jen.Code {
+	if !o.needsCopy(tp) {
+		return nil
+	}
+	if tp.Pointer {
+		elem := tp.Clone()
+		elem.Pointer = false
+		if o.generated(elem) {
+			return []jen.Code{out().Op("=").Add(in()).Dot("DeepCopy").Call()}
+		}
+		body := []jen.Code{
+			out().Op("=").New(elem.Code()),
+			jen.Op("*").Add(out()).Op("=").Op("*").Add(in()),
+		}
+		body = append(body, o.copy(
+			func() *jen.Statement { return jen.Parens(jen.Op("*").Add(in())) },
+			func() *jen.Statement { return jen.Parens(jen.Op("*").Add(out())) },
+			elem,
+			depth,
+		)...)
+		return []jen.Code{jen.If(in().Op("!=").Nil()).Block(body...)}
+	}
+	if o.generated(tp) {
+		return []jen.Code{in().Dot("DeepCopyInto").Call(jen.Op("&").Add(out()))}
+	}
+	if tp.ArrayType != nil {
+		body := []jen.Code{
+			out().Op("=").Make(tp.Code(), jen.Len(in())),
+			jen.Copy(out(), in()),
+		}
+		if o.needsCopy(*tp.ArrayType) {
+			i := loopName("i", depth)
+			body = append(body, jen.For(jen.Id(i).Op(":=").Range().Add(in())).Block(o.copy(
+				func() *jen.Statement { return in().Index(jen.Id(i)) },
+				func() *jen.Statement { return out().Index(jen.Id(i)) },
+				*tp.ArrayType,
+				depth+1,
+			)...))
+		}
+		return []jen.Code{jen.If(in().Op("!=").Nil()).Block(body...)}
+	}
+	if tp.MapType != nil {
+		k, v := loopName("k", depth), loopName("v", depth)
+		loop := []jen.Code{out().Index(jen.Id(k)).Op("=").Id(v)}
+		if o.needsCopy(tp.MapType.Value) {
+			c := loopName("c", depth)
+			loop = append([]jen.Code{jen.Id(c).Op(":=").Id(v)}, o.copy(
+				func() *jen.Statement { return jen.Id(v) },
+				func() *jen.Statement { return jen.Id(c) },
+				tp.MapType.Value,
+				depth+1,
+			)...)
+			loop = append(loop, out().Index(jen.Id(k)).Op("=").Id(c))
+		}
+		return []jen.Code{jen.If(in().Op("!=").Nil()).Block(
+			out().Op("=").Make(tp.Code(), jen.Len(in())),
+			jen.For(jen.List(jen.Id(k), jen.Id(v)).Op(":=").Range().Add(in())).Block(loop...),
+		)}
+	}
+	var code []jen.Code
+	for _, f := range tp.Struct.Fields {
+		name := fieldName(f)
+		code = append(code, o.copy(
+			func() *jen.Statement { return in().Dot(name) },
+			func() *jen.Statement { return out().Dot(name) },
+			f.Type,
+			depth,
+		)...)
+	}
+	return code
+}
+
+// needsCopy returns true if assigning a value of the type does not copy it.
+func (o *deepCopyOptions) needsCopy(tp Type) bool {
+	if tp.RawType != nil || tp.Function != nil {
+		return false
+	}
+	if tp.Pointer || tp.ArrayType != nil || tp.MapType != nil || o.generated(tp) {
+		return true
+	}
+	if tp.Struct != nil {
+		for _, f := range tp.Struct.Fields {
+			if o.needsCopy(f.Type) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// generated returns true if the type has generated deep copy methods.
+func (o *deepCopyOptions) generated(tp Type) bool {
+	return tp.Import == nil && !tp.Pointer && tp.RawType == nil && tp.ArrayType == nil && tp.MapType == nil &&
+		tp.Function == nil && tp.Struct == nil && contains(o.types, tp.Qualifier)
+}
+
+// loopName returns the name of a loop variable at the depth (e.x i, i1, i2).
+func loopName(name string, depth int) string {
+	if depth == 0 {
+		return name
+	}
+	return name + strconv.Itoa(depth)
+}
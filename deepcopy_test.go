@@ -0,0 +1,117 @@
+package code
+
+import (
+	"testing"
+)
+
+func TestAddDeepCopy(t *testing.T) {
+	tests := []struct {
+		name    string
+		fields  []StructField
+		options []DeepCopyOptions
+		want    string
+	}{
+		{
+			name: "Should assign the value fields",
+			fields: []StructField{
+				*NewStructField("Name", NewType("string")),
+				*NewStructField("Created", NewType("Time", ImportTypeOption(*NewImport("", "time")))),
+				*NewStructField("Meta", NewType("Meta")),
+			},
+			want: `package test
+
+import "time"
+
+type Node struct {
+	Name    string
+	Created time.Time
+	Meta    Meta
+}
+
+// DeepCopyInto copies the Node into out, in must not be nil.
+func (in *Node) DeepCopyInto(out *Node) {
+	*out = *in
+}
+
+// DeepCopy returns a deep copy of the Node.
+func (in *Node) DeepCopy() *Node {
+	if in == nil {
+		return nil
+	}
+	out := new(Node)
+	in.DeepCopyInto(out)
+	return out
+}
+`,
+		},
+		{
+			name: "Should copy pointers, slices, maps and generated types",
+			fields: []StructField{
+				*NewStructField("Parent", NewType("Node", PointerTypeOption())),
+				*NewStructField("Children", NewType("", ArrayTypeOption(NewType("Node")))),
+				*NewStructField("Groups", NewType("", MapTypeOption(NewType("string"), NewType("", ArrayTypeOption(NewType("int", PointerTypeOption())))))),
+				*NewStructField("Meta", NewType("Meta")),
+			},
+			options: []DeepCopyOptions{TypesDeepCopyOption("Meta")},
+			want: `package test
+
+type Node struct {
+	Parent   *Node
+	Children []Node
+	Groups   map[string][]*int
+	Meta     Meta
+}
+
+// DeepCopyInto copies the Node into out, in must not be nil.
+func (in *Node) DeepCopyInto(out *Node) {
+	*out = *in
+	out.Parent = in.Parent.DeepCopy()
+	if in.Children != nil {
+		out.Children = make([]Node, len(in.Children))
+		copy(out.Children, in.Children)
+		for i := range in.Children {
+			in.Children[i].DeepCopyInto(&out.Children[i])
+		}
+	}
+	if in.Groups != nil {
+		out.Groups = make(map[string][]*int, len(in.Groups))
+		for k, v := range in.Groups {
+			c := v
+			if v != nil {
+				c = make([]*int, len(v))
+				copy(c, v)
+				for i1 := range v {
+					if v[i1] != nil {
+						c[i1] = new(int)
+						*c[i1] = *v[i1]
+					}
+				}
+			}
+			out.Groups[k] = c
+		}
+	}
+	in.Meta.DeepCopyInto(&out.Meta)
+}
+
+// DeepCopy returns a deep copy of the Node.
+func (in *Node) DeepCopy() *Node {
+	if in == nil {
+		return nil
+	}
+	out := new(Node)
+	in.DeepCopyInto(out)
+	return out
+}
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := NewStructWithFields("Node", tt.fields)
+			AddDeepCopy(st, tt.options...)
+			if got := NewFile("test", st).String(); got != tt.want {
+				t.Errorf("AddDeepCopy() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
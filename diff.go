@@ -0,0 +1,305 @@
+package code
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ChangeKind is the kind of a change reported by Diff.
+type ChangeKind int
+
+const (
+	// ChangeAdded means the element only exists in the new file.
+	ChangeAdded ChangeKind = iota
+
+	// ChangeRemoved means the element only exists in the old file.
+	ChangeRemoved
+
+	// ChangeModified means the element exists in both files but its code changed
+	// (e.x docs, body, field type or tags).
+	ChangeModified
+
+	// ChangeSignature means the receiver, parameters or results of a function or
+	// interface method changed.
+	ChangeSignature
+)
+
+// String returns the name of the change kind.
+func (k ChangeKind) String() string {
+	switch k {
+	case ChangeAdded:
+		return "added"
+	case ChangeRemoved:
+		return "removed"
+	case ChangeModified:
+		return "modified"
+	case ChangeSignature:
+		return "signature changed"
+	}
+	return "unknown"
+}
+
+// Change represents a single difference between two files.
+type Change struct {
+	// Kind is the kind of the change.
+	Kind ChangeKind
+
+	// Name is the qualified name of the changed element
+	// e.x `MyStruct`, `MyStruct.Field`, `MyInterface.Method` or `MyStruct.Method` for methods.
+	// Unnamed code (e.x raw code or comments) uses its go code as the name.
+	Name string
+
+	// Old is the element in the old file, it is nil for added elements.
+	Old Code
+
+	// New is the element in the new file, it is nil for removed elements.
+	New Code
+}
+
+// String returns a short description of the change (e.x `added MyStruct.Field`).
+func (c Change) String() string {
+	return c.Kind.String() + " " + c.Name
+}
+
+// Diff reports the added, removed and changed declarations between the old and new file.
+//
+// Declarations are matched by name, methods are matched by receiver type and name,
+// methods attached to a Struct or TypeDecl are compared as separate declarations.
+// Struct fields and interface methods are compared one by one so changes are reported for each of them,
+// the declaration itself is only reported as modified if its own docs or the order of its fields or methods changed.
+// An empty result means both files generate the same declarations, the order of the declarations in the files is not compared.
+func Diff(oldFile, newFile *File, options ...EqualOptions) []Change {
+	o := newEqualOptions(options)
+	var oldCode, newCode []Code
+	if oldFile != nil {
+		oldCode = oldFile.Code
+	}
+	if newFile != nil {
+		newCode = newFile.Code
+	}
+	oldNames, oldDecls := declarations(expandMethods(oldCode))
+	newNames, newDecls := declarations(expandMethods(newCode))
+
+	var changes []Change
+	for _, name := range oldNames {
+		oc := oldDecls[name]
+		nc, ok := newDecls[name]
+		if !ok {
+			changes = append(changes, Change{Kind: ChangeRemoved, Name: name, Old: oc})
+			continue
+		}
+		changes = append(changes, o.declarationChanges(name, oc, nc)...)
+	}
+	for _, name := range newNames {
+		if _, ok := oldDecls[name]; !ok {
+			changes = append(changes, Change{Kind: ChangeAdded, Name: name, New: newDecls[name]})
+		}
+	}
+	return changes
+}
+
+func (o *equalOptions) declarationChanges(name string, oc, nc Code) []Change {
+	if o.codeEqual(oc, nc) {
+		return nil
+	}
+	modified := []Change{{Kind: ChangeModified, Name: name, Old: oc, New: nc}}
+	switch ov := oc.(type) {
+	case *Struct:
+		nv, ok := nc.(*Struct)
+		if !ok {
+			return modified
+		}
+		if o.structEqual(ov, nv) {
+			// attached methods are compared as separate declarations.
+			return nil
+		}
+		changes := o.fieldChanges(name, ov.Fields, nv.Fields)
+		var oldNames, newNames []string
+		for _, f := range ov.Fields {
+			oldNames = append(oldNames, f.Name)
+		}
+		for _, f := range nv.Fields {
+			newNames = append(newNames, f.Name)
+		}
+		// the structure is modified if the changes of the fields do not explain the difference.
+		if !o.docsEqual(ov.docs, nv.docs) || orderChanged(oldNames, newNames) || len(changes) == 0 {
+			changes = append(modified, changes...)
+		}
+		return changes
+	case *TypeDecl:
+		// attached methods are compared as separate declarations.
+		if nv, ok := nc.(*TypeDecl); ok && o.typeDeclEqual(ov, nv) {
+			return nil
+		}
+	case *Interface:
+		nv, ok := nc.(*Interface)
+		if !ok {
+			return modified
+		}
+		changes := o.methodChanges(name, ov.Methods, nv.Methods)
+		var oldNames, newNames []string
+		for _, m := range ov.Methods {
+			oldNames = append(oldNames, m.Name)
+		}
+		for _, m := range nv.Methods {
+			newNames = append(newNames, m.Name)
+		}
+		if !o.docsEqual(ov.docs, nv.docs) || orderChanged(oldNames, newNames) || len(changes) == 0 {
+			changes = append(modified, changes...)
+		}
+		return changes
+	case *Function:
+		nv, ok := nc.(*Function)
+		if ok && !o.signatureEqual(ov, nv) {
+			modified[0].Kind = ChangeSignature
+		}
+	}
+	return modified
+}
+
+func (o *equalOptions) fieldChanges(parent string, oldFields, newFields []StructField) []Change {
+	var changes []Change
+	newInx := map[string]int{}
+	for i, f := range newFields {
+		newInx[f.Name] = i
+	}
+	oldInx := map[string]int{}
+	for i := range oldFields {
+		of := &oldFields[i]
+		oldInx[of.Name] = i
+		name := parent + "." + of.Name
+		j, ok := newInx[of.Name]
+		if !ok {
+			changes = append(changes, Change{Kind: ChangeRemoved, Name: name, Old: of})
+			continue
+		}
+		nf := &newFields[j]
+		if !o.fieldEqual(*of, *nf) {
+			changes = append(changes, Change{Kind: ChangeModified, Name: name, Old: of, New: nf})
+		}
+	}
+	for i := range newFields {
+		nf := &newFields[i]
+		if _, ok := oldInx[nf.Name]; !ok {
+			changes = append(changes, Change{Kind: ChangeAdded, Name: parent + "." + nf.Name, New: nf})
+		}
+	}
+	return changes
+}
+
+func (o *equalOptions) methodChanges(parent string, oldMethods, newMethods []InterfaceMethod) []Change {
+	var changes []Change
+	newInx := map[string]int{}
+	for i, m := range newMethods {
+		newInx[m.Name] = i
+	}
+	oldInx := map[string]int{}
+	for i := range oldMethods {
+		om := &oldMethods[i]
+		oldInx[om.Name] = i
+		name := parent + "." + om.Name
+		j, ok := newInx[om.Name]
+		if !ok {
+			changes = append(changes, Change{Kind: ChangeRemoved, Name: name, Old: om})
+			continue
+		}
+		nm := &newMethods[j]
+		switch {
+		case !o.signatureEqual((*Function)(om), (*Function)(nm)):
+			changes = append(changes, Change{Kind: ChangeSignature, Name: name, Old: om, New: nm})
+		case !o.docsEqual(om.docs, nm.docs):
+			changes = append(changes, Change{Kind: ChangeModified, Name: name, Old: om, New: nm})
+		}
+	}
+	for i := range newMethods {
+		nm := &newMethods[i]
+		if _, ok := oldInx[nm.Name]; !ok {
+			changes = append(changes, Change{Kind: ChangeAdded, Name: parent + "." + nm.Name, New: nm})
+		}
+	}
+	return changes
+}
+
+// orderChanged tells if the names that are in both lists are not in the same order.
+func orderChanged(oldNames, newNames []string) bool {
+	var oldOrder, newOrder []string
+	for _, n := range oldNames {
+		if contains(newNames, n) {
+			oldOrder = append(oldOrder, n)
+		}
+	}
+	for _, n := range newNames {
+		if contains(oldNames, n) {
+			newOrder = append(newOrder, n)
+		}
+	}
+	for i := range oldOrder {
+		if oldOrder[i] != newOrder[i] {
+			return true
+		}
+	}
+	return false
+}
+
+// declarations returns the declaration names in the order they appear and the declarations by name.
+func declarations(code []Code) ([]string, map[string]Code) {
+	var names []string
+	decls := map[string]Code{}
+	for _, c := range code {
+		if c == nil {
+			continue
+		}
+		name := declarationName(c)
+		if _, ok := decls[name]; ok {
+			// duplicate unnamed code (e.x the same comment twice) is numbered so it is still compared.
+			for i := 2; ; i++ {
+				n := fmt.Sprintf("%s#%d", name, i)
+				if _, ok := decls[n]; !ok {
+					name = n
+					break
+				}
+			}
+		}
+		names = append(names, name)
+		decls[name] = c
+	}
+	return names, decls
+}
+
+func declarationName(c Code) string {
+	if name := codeName(c); name != "" {
+		return name
+	}
+	return c.String()
+}
+
+// codeName returns the declaration name of the code node, methods are named by their receiver type
+// (e.x MyStruct.Method), it returns an empty string for unnamed code.
+func codeName(c Code) string {
+	switch v := c.(type) {
+	case *Var:
+		return v.Name
+	case *Const:
+		return v.Name
+	case *Struct:
+		return v.Name
+	case *TypeDecl:
+		return v.Name
+	case *Interface:
+		return v.Name
+	case *Function:
+		if v.Recv != nil {
+			return receiverTypeName(v.Recv.Type) + "." + v.Name
+		}
+		return v.Name
+	}
+	return ""
+}
+
+// receiverTypeName returns the name of the receiver type without the pointer (e.x *MyStruct => MyStruct).
+func receiverTypeName(tp Type) string {
+	if tp.RawType != nil {
+		return strings.TrimPrefix(tp.String(), "*")
+	}
+	return tp.Qualifier
+}
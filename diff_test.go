@@ -0,0 +1,168 @@
+package code
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/dave/jennifer/jen"
+)
+
+func TestChangeKind_String(t *testing.T) {
+	tests := []struct {
+		name string
+		k    ChangeKind
+		want string
+	}{
+		{name: "Should return added", k: ChangeAdded, want: "added"},
+		{name: "Should return removed", k: ChangeRemoved, want: "removed"},
+		{name: "Should return modified", k: ChangeModified, want: "modified"},
+		{name: "Should return signature changed", k: ChangeSignature, want: "signature changed"},
+		{name: "Should return unknown", k: ChangeKind(100), want: "unknown"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.k.String(); got != tt.want {
+				t.Errorf("ChangeKind.String() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiff(t *testing.T) {
+	service := func(methods ...InterfaceMethod) *Interface {
+		return NewInterface("Service", methods)
+	}
+	get := NewInterfaceMethod("Get",
+		ParamsFunctionOption(*NewParameter("id", NewType("string"))),
+		ResultsFunctionOption(*NewParameter("", NewType("error"))),
+	)
+	getInt := NewInterfaceMethod("Get",
+		ParamsFunctionOption(*NewParameter("id", NewType("int"))),
+		ResultsFunctionOption(*NewParameter("", NewType("error"))),
+	)
+	tests := []struct {
+		name    string
+		oldFile *File
+		newFile *File
+		options []EqualOptions
+		want    []string
+	}{
+		{
+			name:    "Should return no changes for equal files",
+			oldFile: NewFile("test", NewStruct("A"), service(get)),
+			newFile: NewFile("test", NewStruct("A"), service(get)),
+		},
+		{
+			name:    "Should report added and removed declarations",
+			oldFile: NewFile("test", NewStruct("A"), NewVar("b", NewType("string"))),
+			newFile: NewFile("test", NewStruct("A"), NewConst("C", NewType("int"), 1)),
+			want:    []string{"removed b", "added C"},
+		},
+		{
+			name: "Should report struct field changes",
+			oldFile: NewFile("test", NewStructWithFields("A", []StructField{
+				*NewStructField("Name", NewType("string")),
+				*NewStructField("Age", NewType("int")),
+			})),
+			newFile: NewFile("test", NewStructWithFields("A", []StructField{
+				*NewStructField("Name", NewType("string", PointerTypeOption())),
+				*NewStructField("Email", NewType("string")),
+			})),
+			want: []string{"modified A.Name", "removed A.Age", "added A.Email"},
+		},
+		{
+			name: "Should report the struct if its fields are reordered",
+			oldFile: NewFile("test", NewStructWithFields("A", []StructField{
+				*NewStructField("Name", NewType("string")),
+				*NewStructField("Age", NewType("int")),
+			})),
+			newFile: NewFile("test", NewStructWithFields("A", []StructField{
+				*NewStructField("Age", NewType("int")),
+				*NewStructField("Name", NewType("string")),
+				*NewStructField("Email", NewType("string")),
+			})),
+			want: []string{"modified A", "added A.Email"},
+		},
+		{
+			name:    "Should report the interface if its methods are reordered",
+			oldFile: NewFile("test", service(get, NewInterfaceMethod("List"))),
+			newFile: NewFile("test", service(NewInterfaceMethod("List"), get)),
+			want:    []string{"modified Service"},
+		},
+		{
+			name:    "Should report the declaration itself if its docs changed",
+			oldFile: NewFile("test", NewStruct("A", "Old docs")),
+			newFile: NewFile("test", NewStruct("A", "New docs")),
+			want:    []string{"modified A"},
+		},
+		{
+			name:    "Should ignore docs whitespace if configured",
+			oldFile: NewFile("test", NewStruct("A", "Some  docs")),
+			newFile: NewFile("test", NewStruct("A", "Some docs")),
+			options: []EqualOptions{IgnoreDocsWhitespaceEqualOption()},
+		},
+		{
+			name:    "Should report interface method signature changes",
+			oldFile: NewFile("test", service(get, NewInterfaceMethod("List"))),
+			newFile: NewFile("test", service(getInt, NewInterfaceMethod("Put"))),
+			want:    []string{"signature changed Service.Get", "removed Service.List", "added Service.Put"},
+		},
+		{
+			name: "Should report method changes by receiver",
+			oldFile: NewFile("test",
+				NewFunction("Get", RecvFunctionOption(NewParameter("a", NewType("A")))),
+				NewFunction("Get", RecvFunctionOption(NewParameter("b", NewType("B", PointerTypeOption())))),
+			),
+			newFile: NewFile("test",
+				NewFunction("Get", RecvFunctionOption(NewParameter("a", NewType("A"))), BodyFunctionOption(jen.Return())),
+				NewFunction("Get", RecvFunctionOption(NewParameter("b", NewType("B")))),
+			),
+			want: []string{"modified A.Get", "signature changed B.Get"},
+		},
+		{
+			name:    "Should report changed declaration kinds",
+			oldFile: NewFile("test", NewStruct("A")),
+			newFile: NewFile("test", NewInterface("A", nil)),
+			want:    []string{"modified A"},
+		},
+		{
+			name:    "Should compare unnamed code by its code",
+			oldFile: NewFile("test", NewRawCode(jen.Var().Id("_").Op("=").Lit(1))),
+			newFile: NewFile("test", NewRawCode(jen.Var().Id("_").Op("=").Lit(2))),
+			want:    []string{"removed var _ = 1", "added var _ = 2"},
+		},
+		{
+			name:    "Should handle nil files",
+			newFile: NewFile("test", NewStruct("A")),
+			want:    []string{"added A"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, c := range Diff(tt.oldFile, tt.newFile, tt.options...) {
+				got = append(got, c.String())
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Diff() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiff_AttachedMethods(t *testing.T) {
+	oldStruct := NewStruct("A")
+	oldStruct.AddMethod(NewFunction("Get"))
+	oldStruct.AddMethod(NewFunction("List"))
+	newStruct := NewStruct("A")
+	newStruct.AddMethod(NewFunction("Get", ParamsFunctionOption(*NewParameter("id", NewType("string")))))
+	newStruct.AddMethod(NewFunction("Put"))
+	var got []string
+	for _, c := range Diff(NewFile("test", oldStruct), NewFile("test", newStruct)) {
+		got = append(got, c.String())
+	}
+	want := []string{"signature changed A.Get", "removed A.List", "added A.Put"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Diff() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,171 @@
+package code
+
+import (
+	"fmt"
+
+	"github.com/dave/jennifer/jen"
+)
+
+// EnvOptions is used when you call NewEnvLoader, it is a handy way to allow multiple configurations
+// for the generated environment loader.
+type EnvOptions func(o *envOptions)
+
+type envOptions struct {
+	prefix    string
+	separator string
+}
+
+// PrefixEnvOption adds the prefix to the names of the environment variables (e.x APP_ => APP_PORT).
+func PrefixEnvOption(prefix string) EnvOptions {
+	return func(o *envOptions) {
+		o.prefix = prefix
+	}
+}
+
+// SeparatorEnvOption sets the separator of the slice values, the default separator is a comma.
+func SeparatorEnvOption(separator string) EnvOptions {
+	return func(o *envOptions) {
+		o.separator = separator
+	}
+}
+
+// NewEnvLoader creates the Load{Name}FromEnv() (*{Name}, error) function of the structure.
+//
+// The fields are loaded from the environment variables set in their env tags (e.x `env:"PORT"`),
+// fields without an env tag are not loaded. Empty variables use the default tag of the field (e.x `default:"8080"`)
+// and `env:"PORT,required"` marks variables that must be set if the field has no default.
+// The supported field types are strings, bools, numbers, time.Duration, pointers to those types and
+// slices of those types that are read from separated values (e.x a,b,c).
+// The function returns all the invalid values and the missing required variables at once.
+// An error is returned if a field type is not supported.
+func NewEnvLoader(st *Struct, options ...EnvOptions) (*Function, error) {
+	opts := &envOptions{
+		separator: ",",
+	}
+	for _, o := range options {
+		o(opts)
+	}
+	recv := "cfg"
+	body := []jen.Code{
+		jen.Id(recv).Op(":=").Op("&").Id(st.Name).Values(),
+		jen.Var().Id("missing").Index().String(),
+		jen.Var().Id("errs").Index().Error(),
+	}
+	for _, f := range st.Fields {
+		tag := tagOptions(f, "env")
+		if len(tag) == 0 || tag[0] == "" || tag[0] == "-" {
+			continue
+		}
+		name := fieldName(f)
+		env := opts.prefix + tag[0]
+		v := "env" + exportedName(name)
+		target := func() *jen.Statement { return jen.Id(recv).Dot(name) }
+		parse, err := opts.parse(jen.Id(v), f.Type, env, name, target)
+		if err != nil {
+			return nil, fmt.Errorf("the field %s: %w", name, err)
+		}
+		body = append(body, jen.Id(v).Op(":=").Qual("os", "Getenv").Call(jen.Lit(env)))
+		if f.Tags != nil && (*f.Tags)["default"] != "" {
+			body = append(body, jen.If(jen.Id(v).Op("==").Lit("")).Block(jen.Id(v).Op("=").Lit((*f.Tags)["default"])))
+			body = append(body, parse...)
+		} else if contains(tag[1:], "required") {
+			body = append(body, jen.If(jen.Id(v).Op("==").Lit("")).Block(
+				jen.Id("missing").Op("=").Append(jen.Id("missing"), jen.Lit(env)),
+			).Else().Block(parse...))
+		} else {
+			body = append(body, jen.If(jen.Id(v).Op("!=").Lit("")).Block(parse...))
+		}
+	}
+	body = append(
+		body,
+		jen.If(jen.Len(jen.Id("missing")).Op(">").Lit(0)).Block(
+			jen.Id("errs").Op("=").Append(jen.Id("errs"), jen.Qual("fmt", "Errorf").Call(
+				jen.Lit("missing required environment variables: %s"),
+				jen.Qual("strings", "Join").Call(jen.Id("missing"), jen.Lit(", ")),
+			)),
+		),
+		jen.If(jen.Len(jen.Id("errs")).Op(">").Lit(0)).Block(
+			jen.Return(jen.Nil(), jen.Qual("errors", "Join").Call(jen.Id("errs").Op("..."))),
+		),
+		jen.Return(jen.Id(recv), jen.Nil()),
+	)
+	return NewFunction(
+		"Load"+exportedName(st.Name)+"FromEnv",
+		ResultsFunctionOption(
+			*NewParameter("", NewType(st.Name, PointerTypeOption())),
+			*NewParameter("", NewType("error")),
+		),
+		BodyFunctionOption(body...),
+		DocsFunctionOption(
+			Comment("Load"+exportedName(st.Name)+"FromEnv loads the "+st.Name+" from the environment variables,"),
+			"it returns all the invalid values and missing required variables.",
+		),
+	), nil
+}
+
+// parse returns the code that parses the value of the environment variable env and sets it to the target field,
+// pointers are set to the address of a variable named after the field (e.x pTimeout).
+func (o *envOptions) parse(value jen.Code, tp Type, env, field string, target func() *jen.Statement) ([]jen.Code, error) {
+	switch {
+	case tp.Pointer:
+		elem := tp.Clone()
+		elem.Pointer = false
+		p := "p" + exportedName(field)
+		return parseEnvValue(value, elem, env, func(v jen.Code) []jen.Code {
+			return []jen.Code{jen.Id(p).Op(":=").Add(v), target().Op("=").Op("&").Id(p)}
+		})
+	case tp.ArrayType != nil:
+		code, err := parseEnvValue(jen.Qual("strings", "TrimSpace").Call(jen.Id("s")), *tp.ArrayType, env, func(v jen.Code) []jen.Code {
+			return []jen.Code{target().Op("=").Append(target(), v)}
+		})
+		if err != nil {
+			return nil, err
+		}
+		return []jen.Code{jen.For(jen.List(jen.Id("_"), jen.Id("s")).Op(":=").Range().Qual("strings", "Split").Call(
+			value, jen.Lit(o.separator),
+		)).Block(code...)}, nil
+	}
+	return parseEnvValue(value, tp, env, func(v jen.Code) []jen.Code {
+		return []jen.Code{target().Op("=").Add(v)}
+	})
+}
+
+// parseEnvValue returns the code that parses the value of a string, bool, number or time.Duration type
+// and calls set with the parsed value.
+func parseEnvValue(value jen.Code, tp Type, env string, set func(v jen.Code) []jen.Code) ([]jen.Code, error) {
+	var call jen.Code
+	var result string
+	switch {
+	case isDurationType(tp):
+		return parseEnvCall(jen.Qual("time", "ParseDuration").Call(value), jen.Id("v"), env, set), nil
+	case basicKind(tp) == "string":
+		return set(value), nil
+	case basicKind(tp) == "bool":
+		call, result = jen.Qual("strconv", "ParseBool").Call(value), "bool"
+	case basicKind(tp) == "int":
+		call, result = jen.Qual("strconv", "ParseInt").Call(value, jen.Lit(10), jen.Lit(bitSize(tp))), "int64"
+	case basicKind(tp) == "uint":
+		call, result = jen.Qual("strconv", "ParseUint").Call(value, jen.Lit(10), jen.Lit(bitSize(tp))), "uint64"
+	case basicKind(tp) == "float":
+		call, result = jen.Qual("strconv", "ParseFloat").Call(value, jen.Lit(bitSize(tp))), "float64"
+	default:
+		return nil, fmt.Errorf("the type %s is not supported", tp.String())
+	}
+	return parseEnvCall(call, convert(tp.Qualifier, NewType(result), jen.Id("v")), env, set), nil
+}
+
+func parseEnvCall(call, v jen.Code, env string, set func(v jen.Code) []jen.Code) []jen.Code {
+	return []jen.Code{jen.If(
+		jen.List(jen.Id("v"), jen.Err()).Op(":=").Add(call),
+		jen.Err().Op("!=").Nil(),
+	).Block(
+		jen.Id("errs").Op("=").Append(jen.Id("errs"), jen.Qual("fmt", "Errorf").Call(
+			jen.Lit("invalid value of the environment variable "+env+": %w"), jen.Err(),
+		)),
+	).Else().Block(set(v)...)}
+}
+
+func isDurationType(tp Type) bool {
+	return tp.Import != nil && tp.Import.Path == "time" && tp.Qualifier == "Duration" && !tp.Pointer &&
+		tp.RawType == nil && tp.ArrayType == nil && tp.MapType == nil
+}
@@ -0,0 +1,224 @@
+package code
+
+import (
+	"testing"
+)
+
+func TestNewEnvLoader(t *testing.T) {
+	env := func(name, def string) *FieldTags {
+		tags := NewFieldTags("env", name)
+		if def != "" {
+			tags.Set("default", def)
+		}
+		return tags
+	}
+	tests := []struct {
+		name    string
+		fields  []StructField
+		options []EnvOptions
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "Should load the fields from the environment",
+			fields: []StructField{
+				*NewStructFieldWithTag("Addr", NewType("string"), env("ADDR", ":8080")),
+				*NewStructFieldWithTag("Port", NewType("int"), env("PORT,required", "")),
+				*NewStructFieldWithTag("Timeout", NewType("Duration", ImportTypeOption(*NewImport("", "time")), PointerTypeOption()), env("TIMEOUT", "")),
+				*NewStructFieldWithTag("Hosts", NewType("", ArrayTypeOption(NewType("string"))), env("HOSTS", "")),
+				*NewStructField("Other", NewType("string")),
+			},
+			options: []EnvOptions{PrefixEnvOption("APP_"), SeparatorEnvOption(";")},
+			want: `package test
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// LoadConfigFromEnv loads the Config from the environment variables,
+// it returns all the invalid values and missing required variables.
+func LoadConfigFromEnv() (*Config, error) {
+	cfg := &Config{}
+	var missing []string
+	var errs []error
+	envAddr := os.Getenv("APP_ADDR")
+	if envAddr == "" {
+		envAddr = ":8080"
+	}
+	cfg.Addr = envAddr
+	envPort := os.Getenv("APP_PORT")
+	if envPort == "" {
+		missing = append(missing, "APP_PORT")
+	} else {
+		if v, err := strconv.ParseInt(envPort, 10, 0); err != nil {
+			errs = append(errs, fmt.Errorf("invalid value of the environment variable APP_PORT: %w", err))
+		} else {
+			cfg.Port = int(v)
+		}
+	}
+	envTimeout := os.Getenv("APP_TIMEOUT")
+	if envTimeout != "" {
+		if v, err := time.ParseDuration(envTimeout); err != nil {
+			errs = append(errs, fmt.Errorf("invalid value of the environment variable APP_TIMEOUT: %w", err))
+		} else {
+			pTimeout := v
+			cfg.Timeout = &pTimeout
+		}
+	}
+	envHosts := os.Getenv("APP_HOSTS")
+	if envHosts != "" {
+		for _, s := range strings.Split(envHosts, ";") {
+			cfg.Hosts = append(cfg.Hosts, strings.TrimSpace(s))
+		}
+	}
+	if len(missing) > 0 {
+		errs = append(errs, fmt.Errorf("missing required environment variables: %s", strings.Join(missing, ", ")))
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return cfg, nil
+}
+`,
+		},
+		{
+			name: "Should use a variable per pointer field",
+			fields: []StructField{
+				*NewStructFieldWithTag("Host", NewType("string", PointerTypeOption()), env("HOST", "localhost")),
+				*NewStructFieldWithTag("User", NewType("string", PointerTypeOption()), env("USER", "admin")),
+			},
+			want: `package test
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// LoadConfigFromEnv loads the Config from the environment variables,
+// it returns all the invalid values and missing required variables.
+func LoadConfigFromEnv() (*Config, error) {
+	cfg := &Config{}
+	var missing []string
+	var errs []error
+	envHost := os.Getenv("HOST")
+	if envHost == "" {
+		envHost = "localhost"
+	}
+	pHost := envHost
+	cfg.Host = &pHost
+	envUser := os.Getenv("USER")
+	if envUser == "" {
+		envUser = "admin"
+	}
+	pUser := envUser
+	cfg.User = &pUser
+	if len(missing) > 0 {
+		errs = append(errs, fmt.Errorf("missing required environment variables: %s", strings.Join(missing, ", ")))
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return cfg, nil
+}
+`,
+		},
+		{
+			name:    "Should return an error for unsupported types",
+			fields:  []StructField{*NewStructFieldWithTag("Labels", NewType("", MapTypeOption(NewType("string"), NewType("string"))), env("LABELS", ""))},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewEnvLoader(NewStructWithFields("Config", tt.fields), tt.options...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewEnvLoader() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if s := NewFile("test", got).String(); s != tt.want {
+				t.Errorf("NewEnvLoader() = %v, want %v", s, tt.want)
+			}
+		})
+	}
+}
+
+// TestNewEnvLoader_Compile compiles the loader and checks the loaded values and errors.
+func TestNewEnvLoader_Compile(t *testing.T) {
+	env := func(name, def string) *FieldTags {
+		tags := NewFieldTags("env", name)
+		if def != "" {
+			tags.Set("default", def)
+		}
+		return tags
+	}
+	duration := NewType("Duration", ImportTypeOption(*NewImport("", "time")))
+	config := NewStructWithFields("Config", []StructField{
+		*NewStructFieldWithTag("Addr", NewType("string"), env("ADDR", ":8080")),
+		*NewStructFieldWithTag("Port", NewType("uint16"), env("PORT,required", "")),
+		*NewStructFieldWithTag("Debug", NewType("bool", PointerTypeOption()), env("DEBUG", "")),
+		*NewStructFieldWithTag("Ratio", NewType("float32"), env("RATIO", "0.5")),
+		*NewStructFieldWithTag("Timeout", duration, env("TIMEOUT", "5s")),
+		*NewStructFieldWithTag("Retry", duration.Clone(), env("RETRY", "")),
+		*NewStructFieldWithTag("Offsets", NewType("", ArrayTypeOption(NewType("int8"))), env("OFFSETS", "")),
+		*NewStructField("Other", NewType("string")),
+	})
+	loader, err := NewEnvLoader(config, PrefixEnvOption("APP_"))
+	if err != nil {
+		t.Fatalf("NewEnvLoader() error = %v", err)
+	}
+	runGeneratedTests(t, "env", map[string]string{
+		"gen.go":      NewFile("env", config, loader).String(),
+		"gen_test.go": envCompileTest,
+	})
+}
+
+const envCompileTest = `package env
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLoad(t *testing.T) {
+	t.Setenv("APP_PORT", "80")
+	t.Setenv("APP_DEBUG", "true")
+	t.Setenv("APP_RETRY", "1m")
+	t.Setenv("APP_OFFSETS", "1, -2")
+	got, err := LoadConfigFromEnv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	debug := true
+	want := &Config{
+		Addr: ":8080", Port: 80, Debug: &debug, Ratio: 0.5, Timeout: 5 * time.Second, Retry: time.Minute, Offsets: []int8{1, -2},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("LoadConfigFromEnv() = %+v, want %+v", got, want)
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	t.Setenv("APP_DEBUG", "maybe")
+	t.Setenv("APP_OFFSETS", "1,200")
+	_, err := LoadConfigFromEnv()
+	if err == nil {
+		t.Fatal("LoadConfigFromEnv() expected an error")
+	}
+	for _, want := range []string{"APP_DEBUG", "APP_OFFSETS", "missing required environment variables: APP_PORT"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("LoadConfigFromEnv() error = %v, want it to contain %v", err, want)
+		}
+	}
+}
+`
@@ -0,0 +1,301 @@
+package code
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/dave/jennifer/jen"
+)
+
+// EqualOptions is used when you call Equal or Diff, it is a handy way to allow multiple configurations
+// for the comparison.
+type EqualOptions func(o *equalOptions)
+
+type equalOptions struct {
+	ignoreDocsWhitespace bool
+
+	// identity compares types the way the go compiler does, parameter names, docs and
+	// import aliases are ignored.
+	identity bool
+}
+
+// IgnoreDocsWhitespaceEqualOption makes the comparison ignore whitespace differences in documentation comments,
+// e.x `// Hello   World` is equal to `// Hello` `// World`.
+func IgnoreDocsWhitespaceEqualOption() EqualOptions {
+	return func(o *equalOptions) {
+		o.ignoreDocsWhitespace = true
+	}
+}
+
+// Equal compares two code nodes structurally.
+//
+// Two nodes are equal if they are of the same kind and they would generate the same go code,
+// jen statements (function bodies, raw types and raw code) are compared by their rendered go code.
+// Import file paths are ignored because they do not change the generated code,
+// methods attached to a Struct or TypeDecl are compared as well.
+func Equal(a, b Code, options ...EqualOptions) bool {
+	return newEqualOptions(options).codeEqual(a, b)
+}
+
+func newEqualOptions(options []EqualOptions) *equalOptions {
+	o := &equalOptions{}
+	for _, opt := range options {
+		opt(o)
+	}
+	return o
+}
+
+func (o *equalOptions) codeEqual(a, b Code) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	switch av := a.(type) {
+	case Comment:
+		bv, ok := b.(Comment)
+		return ok && o.docsEqual([]Comment{av}, []Comment{bv})
+	case *Comment:
+		bv, ok := b.(*Comment)
+		return ok && o.docsEqual([]Comment{*av}, []Comment{*bv})
+	case Type:
+		bv, ok := b.(Type)
+		return ok && o.typeEqual(av, bv)
+	case *Var:
+		bv, ok := b.(*Var)
+		return ok && o.varEqual(av, bv)
+	case *Const:
+		bv, ok := b.(*Const)
+		return ok && o.varEqual((*Var)(av), (*Var)(bv))
+	case *StructField:
+		bv, ok := b.(*StructField)
+		return ok && o.fieldEqual(*av, *bv)
+	case *Struct:
+		bv, ok := b.(*Struct)
+		return ok && o.structEqual(av, bv) && o.methodsEqual(av.methods, bv.methods)
+	case *TypeDecl:
+		bv, ok := b.(*TypeDecl)
+		return ok && o.typeDeclEqual(av, bv) && o.methodsEqual(av.methods, bv.methods)
+	case *Function:
+		bv, ok := b.(*Function)
+		return ok && o.functionEqual(av, bv)
+	case *InterfaceMethod:
+		bv, ok := b.(*InterfaceMethod)
+		return ok && o.functionEqual((*Function)(av), (*Function)(bv))
+	case *Interface:
+		bv, ok := b.(*Interface)
+		return ok && o.interfaceEqual(av, bv)
+	case *Assertion:
+		bv, ok := b.(*Assertion)
+		return ok && o.typeEqual(av.Interface, bv.Interface) && o.typeEqual(av.Type, bv.Type) &&
+			o.typeParamsEqual(av.TypeParams, bv.TypeParams) && o.docsEqual(av.docs, bv.docs)
+	case *RawCode:
+		bv, ok := b.(*RawCode)
+		return ok && statementEqual(av.code, bv.code)
+	}
+	return reflect.TypeOf(a) == reflect.TypeOf(b) && a.String() == b.String()
+}
+
+func (o *equalOptions) docsEqual(a, b []Comment) bool {
+	if o.identity {
+		return true
+	}
+	if o.ignoreDocsWhitespace {
+		return normalizeDocs(a) == normalizeDocs(b)
+	}
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func (o *equalOptions) typeEqual(a, b Type) bool {
+	if a.RawType != nil || b.RawType != nil {
+		return a.RawType != nil && b.RawType != nil && a.String() == b.String()
+	}
+	if a.Pointer != b.Pointer || a.Variadic != b.Variadic {
+		return false
+	}
+	if (a.ArrayType == nil) != (b.ArrayType == nil) ||
+		(a.MapType == nil) != (b.MapType == nil) ||
+		(a.Function == nil) != (b.Function == nil) ||
+		(a.Struct == nil) != (b.Struct == nil) {
+		return false
+	}
+	switch {
+	case a.ArrayType != nil:
+		return o.typeEqual(*a.ArrayType, *b.ArrayType)
+	case a.MapType != nil:
+		return o.typeEqual(a.MapType.Key, b.MapType.Key) && o.typeEqual(a.MapType.Value, b.MapType.Value)
+	case a.Function != nil && o.identity:
+		return o.signatureEqual((*Function)(a.Function), (*Function)(b.Function))
+	case a.Function != nil:
+		return o.functionEqual((*Function)(a.Function), (*Function)(b.Function))
+	case a.Struct != nil:
+		return o.structEqual((*Struct)(a.Struct), (*Struct)(b.Struct))
+	}
+	if a.Qualifier != b.Qualifier || !o.importEqual(a.Import, b.Import) || len(a.TypeArgs) != len(b.TypeArgs) {
+		return false
+	}
+	for i := range a.TypeArgs {
+		if !o.typeEqual(a.TypeArgs[i], b.TypeArgs[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func (o *equalOptions) varEqual(a, b *Var) bool {
+	return a.Name == b.Name &&
+		o.typeEqual(a.Type, b.Type) &&
+		reflect.DeepEqual(a.Value, b.Value) &&
+		o.docsEqual(a.docs, b.docs)
+}
+
+func (o *equalOptions) paramEqual(a, b Parameter) bool {
+	return (o.identity || a.Name == b.Name) && o.typeEqual(a.Type, b.Type)
+}
+
+func (o *equalOptions) paramsEqual(a, b []Parameter) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !o.paramEqual(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// fieldEqual compares the field names also for identity because they are part of the structure type.
+func (o *equalOptions) fieldEqual(a, b StructField) bool {
+	return a.Name == b.Name && o.typeEqual(a.Type, b.Type) &&
+		tagsEqual(a.Tags, b.Tags) &&
+		o.docsEqual(a.docs, b.docs)
+}
+
+// typeParamsEqual compares the type parameter names also for identity because the types of the declaration use them.
+func (o *equalOptions) typeParamsEqual(a, b []Parameter) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Name != b[i].Name || !o.typeEqual(a[i].Type, b[i].Type) {
+			return false
+		}
+	}
+	return true
+}
+
+func (o *equalOptions) structEqual(a, b *Struct) bool {
+	if a.Name != b.Name || len(a.Fields) != len(b.Fields) || !o.typeParamsEqual(a.TypeParams, b.TypeParams) || !o.docsEqual(a.docs, b.docs) {
+		return false
+	}
+	for i := range a.Fields {
+		if !o.fieldEqual(a.Fields[i], b.Fields[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func (o *equalOptions) typeDeclEqual(a, b *TypeDecl) bool {
+	return a.Name == b.Name && o.typeEqual(a.Type, b.Type) && o.docsEqual(a.docs, b.docs)
+}
+
+func (o *equalOptions) methodsEqual(a, b []*Function) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !o.functionEqual(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// signatureEqual compares the receiver, parameters and results of the functions.
+func (o *equalOptions) signatureEqual(a, b *Function) bool {
+	if (a.Recv == nil) != (b.Recv == nil) {
+		return false
+	}
+	if a.Recv != nil && !o.paramEqual(*a.Recv, *b.Recv) {
+		return false
+	}
+	return o.paramsEqual(a.Params, b.Params) && o.paramsEqual(a.Results, b.Results)
+}
+
+func (o *equalOptions) functionEqual(a, b *Function) bool {
+	return a.Name == b.Name &&
+		o.signatureEqual(a, b) &&
+		bodyEqual(a.Body, b.Body) &&
+		o.docsEqual(a.docs, b.docs)
+}
+
+func (o *equalOptions) interfaceEqual(a, b *Interface) bool {
+	if a.Name != b.Name || len(a.Methods) != len(b.Methods) || !o.typeParamsEqual(a.TypeParams, b.TypeParams) || !o.docsEqual(a.docs, b.docs) {
+		return false
+	}
+	for i := range a.Methods {
+		if !o.functionEqual((*Function)(&a.Methods[i]), (*Function)(&b.Methods[i])) {
+			return false
+		}
+	}
+	return true
+}
+
+func (o *equalOptions) importEqual(a, b *Import) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return a.Path == b.Path && (o.identity || a.Alias == b.Alias)
+}
+
+func tagsEqual(a, b *FieldTags) bool {
+	var at, bt FieldTags
+	if a != nil {
+		at = *a
+	}
+	if b != nil {
+		bt = *b
+	}
+	if len(at) != len(bt) {
+		return false
+	}
+	for k, v := range at {
+		if bv, ok := bt[k]; !ok || bv != v {
+			return false
+		}
+	}
+	return true
+}
+
+func bodyEqual(a, b []jen.Code) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	if len(a) == 0 {
+		return true
+	}
+	return jen.Block(a...).GoString() == jen.Block(b...).GoString()
+}
+
+func statementEqual(a, b *jen.Statement) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return a.GoString() == b.GoString()
+}
+
+func normalizeDocs(docs []Comment) string {
+	var words []string
+	for _, d := range docs {
+		words = append(words, strings.Fields(string(d))...)
+	}
+	return strings.Join(words, " ")
+}
@@ -0,0 +1,210 @@
+package code
+
+import (
+	"testing"
+
+	"github.com/dave/jennifer/jen"
+)
+
+func TestEqual(t *testing.T) {
+	type args struct {
+		a       Code
+		b       Code
+		options []EqualOptions
+	}
+	tests := []struct {
+		name string
+		args args
+		want bool
+	}{
+		{
+			name: "Should return true for two nil nodes",
+			args: args{},
+			want: true,
+		},
+		{
+			name: "Should return false if one node is nil",
+			args: args{
+				a: NewStruct("Test"),
+			},
+			want: false,
+		},
+		{
+			name: "Should return false for different node kinds",
+			args: args{
+				a: NewStruct("Test"),
+				b: NewInterface("Test", nil),
+			},
+			want: false,
+		},
+		{
+			name: "Should return true for equal structures",
+			args: args{
+				a: NewStructWithFields("Test", []StructField{
+					*NewStructFieldWithTag("A", NewType("string"), &FieldTags{"json": "a", "xml": "a"}),
+				}, "Docs"),
+				b: NewStructWithFields("Test", []StructField{
+					*NewStructFieldWithTag("A", NewType("string"), &FieldTags{"xml": "a", "json": "a"}),
+				}, "Docs"),
+			},
+			want: true,
+		},
+		{
+			name: "Should return false for structures with different field types",
+			args: args{
+				a: NewStructWithFields("Test", []StructField{*NewStructField("A", NewType("string"))}),
+				b: NewStructWithFields("Test", []StructField{*NewStructField("A", NewType("string", PointerTypeOption()))}),
+			},
+			want: false,
+		},
+		{
+			name: "Should return false for structures with different docs",
+			args: args{
+				a: NewStruct("Test", "Hello   World"),
+				b: NewStruct("Test", "Hello", "World"),
+			},
+			want: false,
+		},
+		{
+			name: "Should return true for structures with different docs whitespace if ignored",
+			args: args{
+				a:       NewStruct("Test", "Hello   World"),
+				b:       NewStruct("Test", "Hello", "World"),
+				options: []EqualOptions{IgnoreDocsWhitespaceEqualOption()},
+			},
+			want: true,
+		},
+		{
+			name: "Should ignore the import file path",
+			args: args{
+				a: NewType("Context", ImportTypeOption(*NewImportWithFilePath("", "context", "/a"))),
+				b: NewType("Context", ImportTypeOption(*NewImportWithFilePath("", "context", "/b"))),
+			},
+			want: true,
+		},
+		{
+			name: "Should return false for types with different imports",
+			args: args{
+				a: NewType("Context", ImportTypeOption(*NewImport("", "context"))),
+				b: NewType("Context", ImportTypeOption(*NewImport("", "golang.org/x/net/context"))),
+			},
+			want: false,
+		},
+		{
+			name: "Should compare map types",
+			args: args{
+				a: NewType("", MapTypeOption(NewType("string"), NewType("int"))),
+				b: NewType("", MapTypeOption(NewType("string"), NewType("int64"))),
+			},
+			want: false,
+		},
+		{
+			name: "Should compare raw types by their code",
+			args: args{
+				a: NewRawType(jen.Map(jen.String()).Int()),
+				b: NewRawType(jen.Map(jen.String()).Int()),
+			},
+			want: true,
+		},
+		{
+			name: "Should return true for equal functions",
+			args: args{
+				a: NewFunction("Test",
+					ParamsFunctionOption(*NewParameter("a", NewType("string"))),
+					BodyFunctionOption(jen.Return()),
+				),
+				b: NewFunction("Test",
+					ParamsFunctionOption(*NewParameter("a", NewType("string"))),
+					BodyFunctionOption(jen.Return()),
+				),
+			},
+			want: true,
+		},
+		{
+			name: "Should return false for functions with different bodies",
+			args: args{
+				a: NewFunction("Test", BodyFunctionOption(jen.Return())),
+				b: NewFunction("Test", BodyFunctionOption(jen.Panic(jen.Lit("a")))),
+			},
+			want: false,
+		},
+		{
+			name: "Should return false for functions with different receivers",
+			args: args{
+				a: NewFunction("Test", RecvFunctionOption(NewParameter("t", NewType("T")))),
+				b: NewFunction("Test", RecvFunctionOption(NewParameter("t", NewType("T", PointerTypeOption())))),
+			},
+			want: false,
+		},
+		{
+			name: "Should return false for interfaces with different methods",
+			args: args{
+				a: NewInterface("Test", []InterfaceMethod{NewInterfaceMethod("A")}),
+				b: NewInterface("Test", []InterfaceMethod{NewInterfaceMethod("B")}),
+			},
+			want: false,
+		},
+		{
+			name: "Should return false for interfaces with different type parameters",
+			args: args{
+				a: &Interface{Name: "Test", TypeParams: []Parameter{*NewParameter("T", NewType("any"))}},
+				b: &Interface{Name: "Test", TypeParams: []Parameter{*NewParameter("T", NewType("comparable"))}},
+			},
+			want: false,
+		},
+		{
+			name: "Should compare the type arguments",
+			args: args{
+				a: NewType("Repository", TypeArgsTypeOption(NewType("User"))),
+				b: NewType("Repository", TypeArgsTypeOption(NewType("Order"))),
+			},
+			want: false,
+		},
+		{
+			name: "Should compare variables values",
+			args: args{
+				a: NewVarWithValue("a", NewType("int"), 1),
+				b: NewVarWithValue("a", NewType("int"), 2),
+			},
+			want: false,
+		},
+		{
+			name: "Should compare assertion types",
+			args: args{
+				a: NewAssertion(NewType("Service"), NewType("Impl", PointerTypeOption())),
+				b: NewAssertion(NewType("Service"), NewType("Impl")),
+			},
+			want: false,
+		},
+		{
+			name: "Should compare raw code by its code",
+			args: args{
+				a: NewRawCode(jen.Var().Id("a").Op("=").Lit(1)),
+				b: NewRawCode(jen.Var().Id("a").Op("=").Lit(1)),
+			},
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Equal(tt.args.a, tt.args.b, tt.args.options...); got != tt.want {
+				t.Errorf("Equal() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEqual_Clone(t *testing.T) {
+	nodes := []Code{
+		NewStructWithFields("Test", []StructField{*NewStructFieldWithTag("A", NewType("string"), NewFieldTags("json", "a"))}),
+		NewInterface("Test", []InterfaceMethod{NewInterfaceMethod("A", ResultsFunctionOption(*NewParameter("", NewType("error"))))}),
+		NewFunction("Test", BodyFunctionOption(jen.Return())),
+		NewConst("A", NewType("string"), "a"),
+		NewAssertion(NewType("Writer", ImportTypeOption(*NewImport("", "io"))), NewType("A", PointerTypeOption())),
+	}
+	for _, n := range nodes {
+		if !Equal(n, cloneCode(n)) {
+			t.Errorf("Equal() = false for a clone of %v", n)
+		}
+	}
+}
@@ -0,0 +1,273 @@
+package code
+
+import (
+	"github.com/dave/jennifer/jen"
+)
+
+// EqualMethodOptions is used when you call AddEqual, it is a handy way to allow multiple configurations
+// for the generated Equal and Hash methods.
+type EqualMethodOptions func(o *equalMethodOptions)
+
+type equalMethodOptions struct {
+	hash   bool
+	tagKey string
+	types  []string
+}
+
+// HashEqualMethodOption also adds a Hash() uint64 method that is consistent with Equal.
+func HashEqualMethodOption() EqualMethodOptions {
+	return func(o *equalMethodOptions) {
+		o.hash = true
+	}
+}
+
+// TagEqualMethodOption sets the tag key used to exclude fields, the default key is equal (e.x `equal:"-"`).
+func TagEqualMethodOption(key string) EqualMethodOptions {
+	return func(o *equalMethodOptions) {
+		o.tagKey = key
+	}
+}
+
+// TypesEqualMethodOption sets the names of the package types that have generated Equal and Hash methods,
+// fields of those types are compared with their methods.
+func TypesEqualMethodOption(names ...string) EqualMethodOptions {
+	return func(o *equalMethodOptions) {
+		o.types = append(o.types, names...)
+	}
+}
+
+// AddEqual adds an Equal(other *{Name}) bool method to the structure and returns the added methods.
+//
+// The fields are compared following their types, scalars are compared directly, pointers are compared by their
+// nil-ness and then by their values, slices and maps are compared element by element and fields of the structure
+// type or of a type set with TypesEqualMethodOption are compared with their Equal method.
+// time.Time fields are compared with their Equal method and function fields are ignored.
+// Fields tagged with `equal:"-"` are excluded, nil slices and maps are equal to empty ones.
+func AddEqual(st *Struct, options ...EqualMethodOptions) []*Function {
+	opts := &equalMethodOptions{
+		tagKey: "equal",
+		types:  []string{st.Name},
+	}
+	for _, o := range options {
+		o(opts)
+	}
+	recv := freeReceiverName(st.Name, "other", "ok", "h", "i", "k", "v", "w", "sum", "e")
+	var fields []StructField
+	for _, f := range st.Fields {
+		if !hasTagOption(f, opts.tagKey, "-") {
+			fields = append(fields, f)
+		}
+	}
+
+	body := []jen.Code{
+		jen.If(jen.Id(recv).Op("==").Nil().Op("||").Id("other").Op("==").Nil()).Block(
+			jen.Return(jen.Id(recv).Op("==").Id("other")),
+		),
+	}
+	for _, f := range fields {
+		name := fieldName(f)
+		body = append(body, opts.compare(
+			func() *jen.Statement { return jen.Id(recv).Dot(name) },
+			func() *jen.Statement { return jen.Id("other").Dot(name) },
+			f.Type,
+			0,
+		)...)
+	}
+	body = append(body, jen.Return(jen.True()))
+	methods := []*Function{NewFunction(
+		"Equal",
+		ParamsFunctionOption(*NewParameter("other", NewType(st.Name, PointerTypeOption()))),
+		ResultsFunctionOption(*NewParameter("", NewType("bool"))),
+		BodyFunctionOption(body...),
+		DocsFunctionOption(Comment("Equal returns true if the "+st.Name+" is equal to other.")),
+	)}
+
+	if opts.hash {
+		body := []jen.Code{
+			jen.If(jen.Id(recv).Op("==").Nil()).Block(jen.Return(jen.Lit(0))),
+			jen.Id("h").Op(":=").Qual("hash/fnv", "New64a").Call(),
+		}
+		for _, f := range fields {
+			name := fieldName(f)
+			body = append(body, opts.hashCode(
+				func() *jen.Statement { return jen.Id(recv).Dot(name) },
+				"h",
+				f.Type,
+				0,
+			)...)
+		}
+		body = append(body, jen.Return(jen.Id("h").Dot("Sum64").Call()))
+		methods = append(methods, NewFunction(
+			"Hash",
+			ResultsFunctionOption(*NewParameter("", NewType("uint64"))),
+			BodyFunctionOption(body...),
+			DocsFunctionOption(Comment("Hash returns the hash of the "+st.Name+", equal values have the same hash.")),
+		))
+	}
+	for _, m := range methods {
+		st.AddMethod(m, ReceiverNameMethodOption(recv))
+	}
+	return methods
+}
+
+// compare returns the code that returns false if a and b are not equal.
+// The depth is used to name the loop variables of nested slices and maps.
+func (o *equalMethodOptions) compare(a, b func() *jen.Statement, tp Type, depth int) []jen.Code {
+	notEqual := func(cond *jen.Statement) jen.Code {
+		return jen.If(cond).Block(jen.Return(jen.False()))
+	}
+	switch {
+	case tp.Function != nil:
+		return nil
+	case tp.Pointer:
+		elem := tp.Clone()
+		elem.Pointer = false
+		if o.generated(elem) {
+			return []jen.Code{notEqual(jen.Op("!").Add(a()).Dot("Equal").Call(b()))}
+		}
+		code := []jen.Code{notEqual(jen.Parens(a().Op("==").Nil()).Op("!=").Parens(b().Op("==").Nil()))}
+		if o.scalar(elem) {
+			return append(code, notEqual(a().Op("!=").Nil().Op("&&").Op("*").Add(a()).Op("!=").Op("*").Add(b())))
+		}
+		return append(code, jen.If(a().Op("!=").Nil()).Block(o.compare(
+			func() *jen.Statement { return jen.Parens(jen.Op("*").Add(a())) },
+			func() *jen.Statement { return jen.Parens(jen.Op("*").Add(b())) },
+			elem,
+			depth,
+		)...))
+	case o.generated(tp):
+		return []jen.Code{notEqual(jen.Op("!").Add(a()).Dot("Equal").Call(jen.Op("&").Add(b())))}
+	case isTimeType(tp):
+		return []jen.Code{notEqual(jen.Op("!").Add(a()).Dot("Equal").Call(b()))}
+	case tp.ArrayType != nil:
+		i := loopName("i", depth)
+		return []jen.Code{
+			notEqual(jen.Len(a()).Op("!=").Len(b())),
+			jen.For(jen.Id(i).Op(":=").Range().Add(a())).Block(o.compare(
+				func() *jen.Statement { return a().Index(jen.Id(i)) },
+				func() *jen.Statement { return b().Index(jen.Id(i)) },
+				*tp.ArrayType,
+				depth+1,
+			)...),
+		}
+	case tp.MapType != nil:
+		k, v, w := loopName("k", depth), loopName("v", depth), loopName("w", depth)
+		loop := []jen.Code{
+			jen.List(jen.Id(w), jen.Id("ok")).Op(":=").Add(b()).Index(jen.Id(k)),
+			notEqual(jen.Op("!").Id("ok")),
+		}
+		loop = append(loop, o.compare(
+			func() *jen.Statement { return jen.Id(v) },
+			func() *jen.Statement { return jen.Id(w) },
+			tp.MapType.Value,
+			depth+1,
+		)...)
+		return []jen.Code{
+			notEqual(jen.Len(a()).Op("!=").Len(b())),
+			jen.For(jen.List(jen.Id(k), jen.Id(v)).Op(":=").Range().Add(a())).Block(loop...),
+		}
+	case tp.Struct != nil && !o.scalar(tp):
+		var code []jen.Code
+		for _, f := range tp.Struct.Fields {
+			name := fieldName(f)
+			code = append(code, o.compare(
+				func() *jen.Statement { return a().Dot(name) },
+				func() *jen.Statement { return b().Dot(name) },
+				f.Type,
+				depth,
+			)...)
+		}
+		return code
+	}
+	return []jen.Code{notEqual(a().Op("!=").Add(b()))}
+}
+
+// hashCode returns the code that writes the value of a to the hash h.
+func (o *equalMethodOptions) hashCode(a func() *jen.Statement, h string, tp Type, depth int) []jen.Code {
+	write := func(format string, value jen.Code) jen.Code {
+		return jen.Qual("fmt", "Fprintf").Call(jen.Id(h), jen.Lit(format), value)
+	}
+	switch {
+	case tp.Function != nil:
+		return nil
+	case tp.Pointer:
+		elem := tp.Clone()
+		elem.Pointer = false
+		if o.generated(elem) {
+			return []jen.Code{write("%d|", a().Dot("Hash").Call())}
+		}
+		return []jen.Code{jen.If(a().Op("==").Nil()).Block(
+			jen.Qual("fmt", "Fprint").Call(jen.Id(h), jen.Lit("nil|")),
+		).Else().Block(o.hashCode(
+			func() *jen.Statement { return jen.Parens(jen.Op("*").Add(a())) },
+			h,
+			elem,
+			depth,
+		)...)}
+	case o.generated(tp):
+		return []jen.Code{write("%d|", a().Dot("Hash").Call())}
+	case isTimeType(tp):
+		return []jen.Code{write("%d|", a().Dot("UnixNano").Call())}
+	case tp.ArrayType != nil:
+		i := loopName("i", depth)
+		return []jen.Code{
+			write("%d|", jen.Len(a())),
+			jen.For(jen.Id(i).Op(":=").Range().Add(a())).Block(o.hashCode(
+				func() *jen.Statement { return a().Index(jen.Id(i)) },
+				h,
+				*tp.ArrayType,
+				depth+1,
+			)...),
+		}
+	case tp.MapType != nil:
+		// the map entries are hashed separately and summed so the hash does not depend on the iteration order.
+		k, v := loopName("k", depth), loopName("v", depth)
+		sum, e := loopName("sum", depth), loopName("e", depth)
+		loop := []jen.Code{jen.Id(e).Op(":=").Qual("hash/fnv", "New64a").Call()}
+		loop = append(loop, o.hashCode(func() *jen.Statement { return jen.Id(k) }, e, tp.MapType.Key, depth+1)...)
+		loop = append(loop, o.hashCode(func() *jen.Statement { return jen.Id(v) }, e, tp.MapType.Value, depth+1)...)
+		loop = append(loop, jen.Id(sum).Op("+=").Id(e).Dot("Sum64").Call())
+		return []jen.Code{jen.Block(
+			jen.Var().Id(sum).Uint64(),
+			jen.For(jen.List(jen.Id(k), jen.Id(v)).Op(":=").Range().Add(a())).Block(loop...),
+			write("%d|", jen.Id(sum)),
+		)}
+	case tp.Struct != nil:
+		var code []jen.Code
+		for _, f := range tp.Struct.Fields {
+			name := fieldName(f)
+			code = append(code, o.hashCode(func() *jen.Statement { return a().Dot(name) }, h, f.Type, depth)...)
+		}
+		return code
+	}
+	return []jen.Code{write("%v|", a())}
+}
+
+// scalar returns true if the values of the type can be compared with ==.
+func (o *equalMethodOptions) scalar(tp Type) bool {
+	if tp.Pointer {
+		return false
+	}
+	if tp.Function != nil || tp.ArrayType != nil || tp.MapType != nil || o.generated(tp) || isTimeType(tp) {
+		return false
+	}
+	if tp.Struct != nil {
+		for _, f := range tp.Struct.Fields {
+			if !o.scalar(f.Type) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// generated returns true if the type has generated Equal and Hash methods.
+func (o *equalMethodOptions) generated(tp Type) bool {
+	return tp.Import == nil && !tp.Pointer && tp.RawType == nil && tp.ArrayType == nil && tp.MapType == nil &&
+		tp.Function == nil && tp.Struct == nil && contains(o.types, tp.Qualifier)
+}
+
+func isTimeType(tp Type) bool {
+	return tp.Import != nil && tp.Import.Path == "time" && tp.Qualifier == "Time" && !tp.Pointer &&
+		tp.RawType == nil && tp.ArrayType == nil && tp.MapType == nil
+}
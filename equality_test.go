@@ -0,0 +1,260 @@
+package code
+
+import (
+	"testing"
+)
+
+func TestAddEqual(t *testing.T) {
+	tests := []struct {
+		name       string
+		structName string
+		fields     []StructField
+		options    []EqualMethodOptions
+		want       string
+	}{
+		{
+			name:       "Should compare the fields",
+			structName: "Node",
+			fields: []StructField{
+				*NewStructField("Name", NewType("string")),
+				*NewStructField("Parent", NewType("Node", PointerTypeOption())),
+				*NewStructField("Count", NewType("int", PointerTypeOption())),
+				*NewStructField("Labels", NewType("", MapTypeOption(NewType("string"), NewType("", ArrayTypeOption(NewType("string")))))),
+				*NewStructField("Created", NewType("Time", ImportTypeOption(*NewImport("", "time")))),
+				*NewStructFieldWithTag("Cache", NewType("", ArrayTypeOption(NewType("byte"))), NewFieldTags("equal", "-")),
+				*NewStructField("Fn", NewType("", FunctionTypeOption(NewFunctionType()))),
+			},
+			want: `package test
+
+import "time"
+
+type Node struct {
+	Name    string
+	Parent  *Node
+	Count   *int
+	Labels  map[string][]string
+	Created time.Time
+	Cache   []byte ` + "`equal:\"-\"`" + `
+	Fn      func()
+}
+
+// Equal returns true if the Node is equal to other.
+func (n *Node) Equal(other *Node) bool {
+	if n == nil || other == nil {
+		return n == other
+	}
+	if n.Name != other.Name {
+		return false
+	}
+	if !n.Parent.Equal(other.Parent) {
+		return false
+	}
+	if (n.Count == nil) != (other.Count == nil) {
+		return false
+	}
+	if n.Count != nil && *n.Count != *other.Count {
+		return false
+	}
+	if len(n.Labels) != len(other.Labels) {
+		return false
+	}
+	for k, v := range n.Labels {
+		w, ok := other.Labels[k]
+		if !ok {
+			return false
+		}
+		if len(v) != len(w) {
+			return false
+		}
+		for i1 := range v {
+			if v[i1] != w[i1] {
+				return false
+			}
+		}
+	}
+	if !n.Created.Equal(other.Created) {
+		return false
+	}
+	return true
+}
+`,
+		},
+		{
+			name:       "Should add the hash method",
+			structName: "Node",
+			fields: []StructField{
+				*NewStructField("Name", NewType("string")),
+				*NewStructField("Meta", NewType("Meta")),
+				*NewStructField("Labels", NewType("", MapTypeOption(NewType("string"), NewType("int")))),
+				*NewStructField("Count", NewType("int", PointerTypeOption())),
+			},
+			options: []EqualMethodOptions{TypesEqualMethodOption("Meta"), HashEqualMethodOption()},
+			want: `package test
+
+import (
+	"fmt"
+	"hash/fnv"
+)
+
+type Node struct {
+	Name   string
+	Meta   Meta
+	Labels map[string]int
+	Count  *int
+}
+
+// Equal returns true if the Node is equal to other.
+func (n *Node) Equal(other *Node) bool {
+	if n == nil || other == nil {
+		return n == other
+	}
+	if n.Name != other.Name {
+		return false
+	}
+	if !n.Meta.Equal(&other.Meta) {
+		return false
+	}
+	if len(n.Labels) != len(other.Labels) {
+		return false
+	}
+	for k, v := range n.Labels {
+		w, ok := other.Labels[k]
+		if !ok {
+			return false
+		}
+		if v != w {
+			return false
+		}
+	}
+	if (n.Count == nil) != (other.Count == nil) {
+		return false
+	}
+	if n.Count != nil && *n.Count != *other.Count {
+		return false
+	}
+	return true
+}
+
+// Hash returns the hash of the Node, equal values have the same hash.
+func (n *Node) Hash() uint64 {
+	if n == nil {
+		return 0
+	}
+	h := fnv.New64a()
+	fmt.Fprintf(h, "%v|", n.Name)
+	fmt.Fprintf(h, "%d|", n.Meta.Hash())
+	{
+		var sum uint64
+		for k, v := range n.Labels {
+			e := fnv.New64a()
+			fmt.Fprintf(e, "%v|", k)
+			fmt.Fprintf(e, "%v|", v)
+			sum += e.Sum64()
+		}
+		fmt.Fprintf(h, "%d|", sum)
+	}
+	if n.Count == nil {
+		fmt.Fprint(h, "nil|")
+	} else {
+		fmt.Fprintf(h, "%v|", (*n.Count))
+	}
+	return h.Sum64()
+}
+`,
+		},
+		{
+			name:       "Should not use the loop variables as the receiver",
+			structName: "Item",
+			fields:     []StructField{*NewStructField("Tags", NewType("", ArrayTypeOption(NewType("string"))))},
+			want: `package test
+
+type Item struct {
+	Tags []string
+}
+
+// Equal returns true if the Item is equal to other.
+func (item *Item) Equal(other *Item) bool {
+	if item == nil || other == nil {
+		return item == other
+	}
+	if len(item.Tags) != len(other.Tags) {
+		return false
+	}
+	for i := range item.Tags {
+		if item.Tags[i] != other.Tags[i] {
+			return false
+		}
+	}
+	return true
+}
+`,
+		},
+		{
+			name:       "Should not use the hash variable as the receiver",
+			structName: "Host",
+			fields:     []StructField{*NewStructField("Name", NewType("string"))},
+			options:    []EqualMethodOptions{HashEqualMethodOption()},
+			want: `package test
+
+import (
+	"fmt"
+	"hash/fnv"
+)
+
+type Host struct {
+	Name string
+}
+
+// Equal returns true if the Host is equal to other.
+func (host *Host) Equal(other *Host) bool {
+	if host == nil || other == nil {
+		return host == other
+	}
+	if host.Name != other.Name {
+		return false
+	}
+	return true
+}
+
+// Hash returns the hash of the Host, equal values have the same hash.
+func (host *Host) Hash() uint64 {
+	if host == nil {
+		return 0
+	}
+	h := fnv.New64a()
+	fmt.Fprintf(h, "%v|", host.Name)
+	return h.Sum64()
+}
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := NewStructWithFields(tt.structName, tt.fields)
+			AddEqual(st, tt.options...)
+			if got := NewFile("test", st).String(); got != tt.want {
+				t.Errorf("AddEqual() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_freeReceiverName(t *testing.T) {
+	tests := []struct {
+		typeName string
+		used     []string
+		want     string
+	}{
+		{typeName: "Node", used: []string{"i", "k"}, want: "n"},
+		{typeName: "Item", used: []string{"i", "k"}, want: "item"},
+		{typeName: "Index", used: []string{"i1"}, want: "i"},
+		{typeName: "Key", used: []string{"k", "key"}, want: "recv"},
+		{typeName: "Key", used: []string{"k", "key", "recv"}, want: "recv_"},
+		{typeName: "Type", used: []string{"t"}, want: "type_"},
+	}
+	for _, tt := range tests {
+		if got := freeReceiverName(tt.typeName, tt.used...); got != tt.want {
+			t.Errorf("freeReceiverName(%s, %v) = %v, want %v", tt.typeName, tt.used, got, tt.want)
+		}
+	}
+}
@@ -1,8 +1,9 @@
 package code
 
 import (
+	"fmt"
+
 	"github.com/dave/jennifer/jen"
-	"github.com/pkg/errors"
 )
 
 // ImportAlias is used to specify the import alias in a file
@@ -15,6 +16,7 @@ type ImportAlias struct {
 type File struct {
 	pkg     string
 	jenFile *jen.File
+	aliases []ImportAlias
 
 	Code []Code
 }
@@ -43,19 +45,26 @@ func (f *File) SetImportAliases(ia []ImportAlias) {
 	if f.jenFile == nil {
 		return
 	}
+	f.aliases = append(f.aliases, ia...)
 	for _, i := range ia {
 		f.jenFile.ImportAlias(i.Path, i.Name)
 	}
 }
 
 // String returns the go source string of the file,
-// if the jen representation of the file is nil it will return a basic file with package
+// if the jen representation of the file is nil it will return a basic file with package.
+//
+// Code nodes are separated by an empty line and methods attached to a Struct or TypeDecl
+// are rendered right after their type.
 func (f *File) String() string {
 	if f.jenFile == nil {
 		return "package " + f.pkg + "\n"
 	}
 	var ia []ImportAlias
-	for _, c := range f.Code {
+	for i, c := range expandMethods(f.Code) {
+		if i > 0 {
+			f.jenFile.Line()
+		}
 		f.jenFile.Add(c.Code())
 		if c.ImportAliases() != nil {
 			ia = append(ia, c.ImportAliases()...)
@@ -67,49 +76,205 @@ func (f *File) String() string {
 	return f.jenFile.GoString()
 }
 
-// AppendAfter appends a new code node after the given code node.
-func (f *File) AppendAfter(c Code, new Code) error {
-	inx := -1
-	for i, v := range f.Code {
-		if v == c {
-			inx = i + 1
-			break
+// NotFoundError is returned when a code node or a declaration could not be found in the file.
+type NotFoundError struct {
+	// Name is the declaration name that was searched for, it is empty if a code node was searched for.
+	Name string
+
+	// Node is the code node that was searched for, it is nil if a declaration name was searched for.
+	Node Code
+}
+
+// Error returns the error message.
+func (e *NotFoundError) Error() string {
+	if e.Node == nil {
+		return "could not find the declaration " + e.Name
+	}
+	return "could not find the code node in the file"
+}
+
+// Find returns the declaration with the given name,
+// methods are found using the receiver type name and the method name (e.x MyStruct.Method).
+//
+// If there is no declaration with the given name a *NotFoundError is returned.
+func (f *File) Find(name string) (Code, error) {
+	for _, c := range expandMethods(f.Code) {
+		if c != nil && codeName(c) == name {
+			return c, nil
+		}
+	}
+	return nil, &NotFoundError{Name: name}
+}
+
+// Structs returns all the structures of the file.
+func (f *File) Structs() []*Struct {
+	var structs []*Struct
+	for _, c := range f.Code {
+		if s, ok := c.(*Struct); ok {
+			structs = append(structs, s)
 		}
 	}
+	return structs
+}
+
+// Interfaces returns all the interfaces of the file.
+func (f *File) Interfaces() []*Interface {
+	var interfaces []*Interface
+	for _, c := range f.Code {
+		if i, ok := c.(*Interface); ok {
+			interfaces = append(interfaces, i)
+		}
+	}
+	return interfaces
+}
+
+// Functions returns all the functions of the file that do not have a receiver.
+func (f *File) Functions() []*Function {
+	var functions []*Function
+	for _, c := range f.Code {
+		if fn, ok := c.(*Function); ok && fn.Recv == nil {
+			functions = append(functions, fn)
+		}
+	}
+	return functions
+}
+
+// Methods returns all the methods of the file with the given receiver type name,
+// pointer and value receivers are both returned (e.x MyStruct returns methods of MyStruct and *MyStruct).
+// Methods attached to a Struct or TypeDecl are also returned.
+func (f *File) Methods(recvType string) []*Function {
+	var methods []*Function
+	for _, c := range expandMethods(f.Code) {
+		if fn, ok := c.(*Function); ok && fn.Recv != nil && receiverTypeName(fn.Recv.Type) == recvType {
+			methods = append(methods, fn)
+		}
+	}
+	return methods
+}
+
+// Remove removes the given code node from the file,
+// methods attached to a Struct or TypeDecl of the file are detached from their type.
+//
+// If the code node is not in the file a *NotFoundError is returned.
+func (f *File) Remove(c Code) error {
+	inx := f.index(c)
+	if inx != -1 {
+		f.Code = append(f.Code[:inx], f.Code[inx+1:]...)
+		return nil
+	}
+	methods, inx := f.attached(c)
 	if inx == -1 {
-		return errors.New("Could not find the code node to append after")
+		return &NotFoundError{Node: c}
 	}
-	if inx == len(f.Code) {
-		f.Code = append(f.Code, new)
+	*methods = append((*methods)[:inx], (*methods)[inx+1:]...)
+	return nil
+}
+
+// Replace replaces the old code node with the new code node keeping its position in the file.
+//
+// Methods attached to a Struct or TypeDecl of the file are replaced in their type,
+// the new code node must then be a *Function, it is attached as it is (its receiver is not changed).
+// If the old code node is not in the file a *NotFoundError is returned.
+func (f *File) Replace(old Code, new Code) error {
+	inx := f.index(old)
+	if inx != -1 {
+		f.Code[inx] = new
 		return nil
 	}
-	f.Code = append(
-		f.Code[:inx],
-		append([]Code{new}, f.Code[inx:]...)...,
-	)
+	methods, inx := f.attached(old)
+	if inx == -1 {
+		return &NotFoundError{Node: old}
+	}
+	fn, ok := new.(*Function)
+	if !ok {
+		return fmt.Errorf("the attached method %s can only be replaced by a function", codeName(old))
+	}
+	(*methods)[inx] = fn
+	return nil
+}
+
+// AppendAfter appends a new code node after the given code node.
+//
+// If the given code node is not in the file a *NotFoundError is returned,
+// methods attached to a Struct or TypeDecl are not top level code nodes so they are not found.
+func (f *File) AppendAfter(c Code, new Code) error {
+	inx := f.index(c)
+	if inx == -1 {
+		return &NotFoundError{Node: c}
+	}
+	f.insert(inx+1, new)
 	return nil
 }
 
 // PrependBefore prepends a new code node before the given code node.
+//
+// If the given code node is not in the file a *NotFoundError is returned,
+// methods attached to a Struct or TypeDecl are not top level code nodes so they are not found.
 func (f *File) PrependBefore(c Code, new Code) error {
-	inx := -1
-	for i, v := range f.Code {
-		if v == c {
-			inx = i
-			break
+	inx := f.index(c)
+	if inx == -1 {
+		return &NotFoundError{Node: c}
+	}
+	f.insert(inx, new)
+	return nil
+}
+
+// expandMethods returns the code nodes with the attached methods placed right after their type.
+func expandMethods(code []Code) []Code {
+	var expanded []Code
+	for _, c := range code {
+		expanded = append(expanded, c)
+		if mh, ok := c.(MethodSet); ok {
+			for _, m := range mh.Methods() {
+				expanded = append(expanded, m)
+			}
 		}
 	}
-	if inx == -1 {
-		return errors.New("Could not find the code node to append after")
+	return expanded
+}
+
+// attached returns the methods of the Struct or TypeDecl of the file that has the given method attached
+// and the index of the method, the index is -1 if the method is not attached to a type of the file.
+func (f *File) attached(c Code) (*[]*Function, int) {
+	fn, ok := c.(*Function)
+	if !ok {
+		return nil, -1
 	}
-	if inx == 0 {
-		f.Code = append([]Code{new}, f.Code...)
-		return nil
+	for _, v := range f.Code {
+		var methods *[]*Function
+		switch t := v.(type) {
+		case *Struct:
+			methods = &t.methods
+		case *TypeDecl:
+			methods = &t.methods
+		default:
+			continue
+		}
+		for i, m := range *methods {
+			if m == fn {
+				return methods, i
+			}
+		}
+	}
+	return nil, -1
+}
+
+func (f *File) index(c Code) int {
+	for i, v := range f.Code {
+		if v == c {
+			return i
+		}
 	}
+	return -1
+}
 
+func (f *File) insert(inx int, new Code) {
+	if inx == len(f.Code) {
+		f.Code = append(f.Code, new)
+		return
+	}
 	f.Code = append(
 		f.Code[:inx],
 		append([]Code{new}, f.Code[inx:]...)...,
 	)
-	return nil
 }
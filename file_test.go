@@ -484,3 +484,276 @@ func TestFile_PrependBefore(t *testing.T) {
 		})
 	}
 }
+
+func TestNotFoundError_Error(t *testing.T) {
+	tests := []struct {
+		name string
+		err  *NotFoundError
+		want string
+	}{
+		{
+			name: "Should return the error message for a declaration name",
+			err:  &NotFoundError{Name: "MyStruct"},
+			want: "could not find the declaration MyStruct",
+		},
+		{
+			name: "Should return the error message for a code node",
+			err:  &NotFoundError{Node: NewStruct("MyStruct")},
+			want: "could not find the code node in the file",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.err.Error(); got != tt.want {
+				t.Errorf("NotFoundError.Error() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFile_Find(t *testing.T) {
+	st := NewStruct("MyStruct")
+	inf := NewInterface("MyInterface", nil)
+	fn := NewFunction("MyFunction")
+	method := NewFunction("MyFunction", RecvFunctionOption(NewParameter("s", NewType("MyStruct", PointerTypeOption()))))
+	v := NewVar("myVar", NewType("string"))
+	c := NewConst("MyConst", NewType("string"), "a")
+	f := NewFile("test", NewRawCode(jen.Comment("raw")), st, inf, fn, method, v, c)
+	tests := []struct {
+		name    string
+		arg     string
+		want    Code
+		wantErr bool
+	}{
+		{name: "Should find the structure", arg: "MyStruct", want: st},
+		{name: "Should find the interface", arg: "MyInterface", want: inf},
+		{name: "Should find the function", arg: "MyFunction", want: fn},
+		{name: "Should find the method", arg: "MyStruct.MyFunction", want: method},
+		{name: "Should find the variable", arg: "myVar", want: v},
+		{name: "Should find the constant", arg: "MyConst", want: c},
+		{name: "Should return error if the declaration is not found", arg: "Missing", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := f.Find(tt.arg)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("File.Find() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				if e, ok := err.(*NotFoundError); !ok || e.Name != tt.arg {
+					t.Errorf("File.Find() error = %#v, want *NotFoundError", err)
+				}
+				return
+			}
+			if got != tt.want {
+				t.Errorf("File.Find() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFile_Lists(t *testing.T) {
+	st1 := NewStruct("A")
+	st2 := NewStruct("B")
+	inf := NewInterface("C", nil)
+	fn := NewFunction("D")
+	m1 := NewFunction("E", RecvFunctionOption(NewParameter("a", NewType("A", PointerTypeOption()))))
+	m2 := NewFunction("F", RecvFunctionOption(NewParameter("a", NewType("A"))))
+	m3 := NewFunction("G", RecvFunctionOption(NewParameter("b", NewType("B"))))
+	f := NewFile("test", st1, m1, inf, fn, st2, m2, m3)
+
+	if got, want := f.Structs(), []*Struct{st1, st2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("File.Structs() = %v, want %v", got, want)
+	}
+	if got, want := f.Interfaces(), []*Interface{inf}; !reflect.DeepEqual(got, want) {
+		t.Errorf("File.Interfaces() = %v, want %v", got, want)
+	}
+	if got, want := f.Functions(), []*Function{fn}; !reflect.DeepEqual(got, want) {
+		t.Errorf("File.Functions() = %v, want %v", got, want)
+	}
+	if got, want := f.Methods("A"), []*Function{m1, m2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("File.Methods() = %v, want %v", got, want)
+	}
+	if got := f.Methods("C"); got != nil {
+		t.Errorf("File.Methods() = %v, want nil", got)
+	}
+}
+
+func TestFile_Remove(t *testing.T) {
+	inf := NewInterface("SomeInterface", nil)
+	fn := NewFunction("MyMethod")
+	tests := []struct {
+		name    string
+		code    []Code
+		arg     Code
+		want    []Code
+		wantErr bool
+	}{
+		{
+			name: "Should remove the code node",
+			code: []Code{fn, inf},
+			arg:  inf,
+			want: []Code{fn},
+		},
+		{
+			name: "Should remove the code node in the middle",
+			code: []Code{NewStruct("A"), inf, fn},
+			arg:  inf,
+			want: []Code{NewStruct("A"), fn},
+		},
+		{
+			name:    "Should return error if the given code is not found",
+			code:    []Code{fn},
+			arg:     inf,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := NewFile("test", tt.code...)
+			err := f.Remove(tt.arg)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("File.Remove() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				if _, ok := err.(*NotFoundError); !ok {
+					t.Errorf("File.Remove() error = %#v, want *NotFoundError", err)
+				}
+				return
+			}
+			if !reflect.DeepEqual(f.Code, tt.want) {
+				t.Errorf("File.Code = %v, want %v", f.Code, tt.want)
+			}
+		})
+	}
+}
+
+func TestFile_Replace(t *testing.T) {
+	inf := NewInterface("SomeInterface", nil)
+	fn := NewFunction("MyMethod")
+	tests := []struct {
+		name    string
+		code    []Code
+		old     Code
+		new     Code
+		want    []Code
+		wantErr bool
+	}{
+		{
+			name: "Should replace the code node keeping its position",
+			code: []Code{NewStruct("A"), inf, NewStruct("B")},
+			old:  inf,
+			new:  fn,
+			want: []Code{NewStruct("A"), fn, NewStruct("B")},
+		},
+		{
+			name:    "Should return error if the given code is not found",
+			code:    []Code{NewStruct("A")},
+			old:     inf,
+			new:     fn,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := NewFile("test", tt.code...)
+			err := f.Replace(tt.old, tt.new)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("File.Replace() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				if _, ok := err.(*NotFoundError); !ok {
+					t.Errorf("File.Replace() error = %#v, want *NotFoundError", err)
+				}
+				return
+			}
+			if !reflect.DeepEqual(f.Code, tt.want) {
+				t.Errorf("File.Code = %v, want %v", f.Code, tt.want)
+			}
+		})
+	}
+}
+
+func TestFile_String_Methods(t *testing.T) {
+	st := NewStruct("MyStruct")
+	st.AddMethod(NewFunction("Print", BodyFunctionOption(jen.Qual("fmt", "Println").Call())))
+	td := NewTypeDecl("Celsius", NewType("float64"))
+	td.AddMethod(NewFunction("String",
+		ResultsFunctionOption(*NewParameter("", NewType("string"))),
+		BodyFunctionOption(jen.Return(jen.Lit(""))),
+	), ValueReceiverMethodOption())
+	f := NewFile("test", st, td, NewFunction("Other"))
+	want := "package test\n\nimport \"fmt\"\n\n" +
+		"type MyStruct struct{}\n\nfunc (m *MyStruct) Print() {\n\tfmt.Println()\n}\n\n" +
+		"type Celsius float64\n\nfunc (c Celsius) String() string {\n\treturn \"\"\n}\n\n" +
+		"func Other() {}\n"
+	if got := f.String(); got != want {
+		t.Errorf("File.String() = %v, want %v", got, want)
+	}
+	if got := f.Methods("MyStruct"); len(got) != 1 || got[0] != st.Methods()[0] {
+		t.Errorf("File.Methods() = %v, want %v", got, st.Methods())
+	}
+	if got, err := f.Find("Celsius.String"); err != nil || got != td.Methods()[0] {
+		t.Errorf("File.Find() = %v, %v, want %v", got, err, td.Methods()[0])
+	}
+}
+
+func TestFile_String_EmptyLines(t *testing.T) {
+	// every top level node is separated by an empty line, not only the attached methods.
+	f := NewFile("test", NewFunction("A"), NewFunction("B"), NewStruct("C"))
+	want := "package test\n\nfunc A() {}\n\nfunc B() {}\n\ntype C struct{}\n"
+	if got := f.String(); got != want {
+		t.Errorf("File.String() = %v, want %v", got, want)
+	}
+}
+
+func TestFile_RemoveReplace_AttachedMethods(t *testing.T) {
+	newFile := func() (*File, *Struct) {
+		st := NewStruct("S")
+		st.AddMethod(NewFunction("M"))
+		st.AddMethod(NewFunction("N"))
+		return NewFile("test", st), st
+	}
+	tests := []struct {
+		name    string
+		change  func(f *File, m Code) error
+		want    []string
+		wantErr bool
+	}{
+		{
+			name:   "Should detach the removed method",
+			change: func(f *File, m Code) error { return f.Remove(m) },
+			want:   []string{"N"},
+		},
+		{
+			name:   "Should replace the attached method",
+			change: func(f *File, m Code) error { return f.Replace(m, NewFunction("O")) },
+			want:   []string{"O", "N"},
+		},
+		{
+			name:    "Should return an error if the attached method is replaced by other code",
+			change:  func(f *File, m Code) error { return f.Replace(m, NewStruct("O")) },
+			want:    []string{"M", "N"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, st := newFile()
+			m, err := f.Find("S.M")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := tt.change(f, m); (err != nil) != tt.wantErr {
+				t.Fatalf("change error = %v, wantErr %v", err, tt.wantErr)
+			}
+			var got []string
+			for _, m := range st.Methods() {
+				got = append(got, m.Name)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Struct.Methods() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package code
+
+import (
+	"fmt"
+
+	"github.com/dave/jennifer/jen"
+)
+
+// NewFlagRegistrar creates the Register{Name}Flags(fs *flag.FlagSet, cfg *{Name}) function of the structure.
+//
+// The fields are registered with the names set in their flag tags (e.x `flag:"addr" usage:"the listen address"`),
+// fields without a flag tag are not registered. The current values of cfg are the flag defaults.
+// Fields of the types supported by flag.FlagSet (string, bool, int, int64, uint, uint64, float64 and time.Duration)
+// are registered with the matching FlagSet method (e.x fs.StringVar).
+// Slices of strings, bools, numbers and time.Duration are registered with a flag.Value type that is returned
+// with the function, every use of the flag adds a value to the slice.
+// An error is returned if a field type is not supported.
+func NewFlagRegistrar(st *Struct) ([]Code, error) {
+	var body []jen.Code
+	var values []Code
+	for _, f := range st.Fields {
+		tag := tagOptions(f, "flag")
+		if len(tag) == 0 || tag[0] == "" || tag[0] == "-" {
+			continue
+		}
+		name := fieldName(f)
+		usage := ""
+		if f.Tags != nil {
+			usage = (*f.Tags)["usage"]
+		}
+		field := jen.Op("&").Id("cfg").Dot(name)
+		if method := flagMethod(f.Type); method != "" {
+			body = append(body, jen.Id("fs").Dot(method).Call(field, jen.Lit(tag[0]), jen.Id("cfg").Dot(name), jen.Lit(usage)))
+			continue
+		}
+		if f.Type.ArrayType == nil || f.Type.Pointer {
+			return nil, fmt.Errorf("the field %s: the type %s is not supported", name, f.Type.String())
+		}
+		value, err := newFlagValue(unexportedName(st.Name)+exportedName(name)+"Flag", name, st.Name, f.Type)
+		if err != nil {
+			return nil, fmt.Errorf("the field %s: %w", name, err)
+		}
+		values = append(values, value)
+		body = append(body, jen.Id("fs").Dot("Var").Call(jen.Parens(jen.Op("*").Id(value.Name)).Parens(field), jen.Lit(tag[0]), jen.Lit(usage)))
+	}
+	fn := NewFunction(
+		"Register"+exportedName(st.Name)+"Flags",
+		ParamsFunctionOption(
+			*NewParameter("fs", NewType("FlagSet", ImportTypeOption(Import{Path: "flag"}), PointerTypeOption())),
+			*NewParameter("cfg", NewType(st.Name, PointerTypeOption())),
+		),
+		BodyFunctionOption(body...),
+		DocsFunctionOption(
+			Comment("Register"+exportedName(st.Name)+"Flags registers the flags of the "+st.Name+" fields in fs,"),
+			"the current values of cfg are the flag defaults.",
+		),
+	)
+	return append([]Code{fn}, values...), nil
+}
+
+// flagMethod returns the flag.FlagSet method that registers the type (e.x StringVar),
+// it returns an empty string if there is no method for the type.
+func flagMethod(tp Type) string {
+	if isDurationType(tp) {
+		return "DurationVar"
+	}
+	if tp.Pointer || tp.Import != nil || tp.RawType != nil || tp.ArrayType != nil || tp.MapType != nil ||
+		tp.Function != nil || tp.Struct != nil {
+		return ""
+	}
+	switch tp.Qualifier {
+	case "string", "bool", "int", "int64", "uint", "uint64", "float64":
+		return exportedName(tp.Qualifier) + "Var"
+	}
+	return ""
+}
+
+// newFlagValue creates the flag.Value type of the slice field.
+func newFlagValue(name, field, structName string, tp Type) (*TypeDecl, error) {
+	elem := *tp.ArrayType
+	set := func(v jen.Code) jen.Code {
+		return jen.Op("*").Id("f").Op("=").Append(jen.Op("*").Id("f"), v)
+	}
+	var body []jen.Code
+	var call jen.Code
+	var result string
+	switch {
+	case isDurationType(elem):
+		call, result = jen.Qual("time", "ParseDuration").Call(jen.Id("s")), "Duration"
+	case basicKind(elem) == "string":
+		body = []jen.Code{set(jen.Id("s")), jen.Return(jen.Nil())}
+	case basicKind(elem) == "bool":
+		call, result = jen.Qual("strconv", "ParseBool").Call(jen.Id("s")), "bool"
+	case basicKind(elem) == "int":
+		call, result = jen.Qual("strconv", "ParseInt").Call(jen.Id("s"), jen.Lit(10), jen.Lit(bitSize(elem))), "int64"
+	case basicKind(elem) == "uint":
+		call, result = jen.Qual("strconv", "ParseUint").Call(jen.Id("s"), jen.Lit(10), jen.Lit(bitSize(elem))), "uint64"
+	case basicKind(elem) == "float":
+		call, result = jen.Qual("strconv", "ParseFloat").Call(jen.Id("s"), jen.Lit(bitSize(elem))), "float64"
+	default:
+		return nil, fmt.Errorf("the type %s is not supported", tp.String())
+	}
+	if call != nil {
+		var v jen.Code = jen.Id("v")
+		if result != "Duration" {
+			v = convert(elem.Qualifier, NewType(result), jen.Id("v"))
+		}
+		body = []jen.Code{
+			jen.List(jen.Id("v"), jen.Err()).Op(":=").Add(call),
+			jen.If(jen.Err().Op("!=").Nil()).Block(jen.Return(jen.Err())),
+			set(v),
+			jen.Return(jen.Nil()),
+		}
+	}
+	value := NewTypeDecl(
+		name,
+		tp.Clone(),
+		Comment(name+" is the flag.Value of the "+field+" field of "+structName+", every use of the flag adds a value."),
+	)
+	value.AddMethod(NewFunction(
+		"String",
+		ResultsFunctionOption(*NewParameter("", NewType("string"))),
+		BodyFunctionOption(
+			jen.If(jen.Id("f").Op("==").Nil()).Block(jen.Return(jen.Lit(""))),
+			jen.Return(jen.Qual("fmt", "Sprint").Call(jen.Add(tp.Code()).Parens(jen.Op("*").Id("f")))),
+		),
+		DocsFunctionOption("String returns the values of the flag."),
+	), ReceiverNameMethodOption("f"))
+	value.AddMethod(NewFunction(
+		"Set",
+		ParamsFunctionOption(*NewParameter("s", NewType("string"))),
+		ResultsFunctionOption(*NewParameter("", NewType("error"))),
+		BodyFunctionOption(body...),
+		DocsFunctionOption("Set adds the value to the flag values."),
+	), ReceiverNameMethodOption("f"))
+	return value, nil
+}
@@ -0,0 +1,85 @@
+package code
+
+import (
+	"testing"
+)
+
+func TestNewFlagRegistrar(t *testing.T) {
+	flag := func(name, usage string) *FieldTags {
+		tags := NewFieldTags("flag", name)
+		tags.Set("usage", usage)
+		return tags
+	}
+	tests := []struct {
+		name    string
+		fields  []StructField
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "Should register the flags",
+			fields: []StructField{
+				*NewStructFieldWithTag("Addr", NewType("string"), flag("addr", "the listen address")),
+				*NewStructFieldWithTag("Timeout", NewType("Duration", ImportTypeOption(*NewImport("", "time"))), flag("timeout", "")),
+				*NewStructFieldWithTag("Ports", NewType("", ArrayTypeOption(NewType("uint16"))), flag("port", "a port")),
+				*NewStructField("Other", NewType("string")),
+			},
+			want: `package test
+
+import (
+	"flag"
+	"fmt"
+	"strconv"
+)
+
+// RegisterConfigFlags registers the flags of the Config fields in fs,
+// the current values of cfg are the flag defaults.
+func RegisterConfigFlags(fs *flag.FlagSet, cfg *Config) {
+	fs.StringVar(&cfg.Addr, "addr", cfg.Addr, "the listen address")
+	fs.DurationVar(&cfg.Timeout, "timeout", cfg.Timeout, "")
+	fs.Var((*configPortsFlag)(&cfg.Ports), "port", "a port")
+}
+
+// configPortsFlag is the flag.Value of the Ports field of Config, every use of the flag adds a value.
+type configPortsFlag []uint16
+
+// String returns the values of the flag.
+func (f *configPortsFlag) String() string {
+	if f == nil {
+		return ""
+	}
+	return fmt.Sprint([]uint16(*f))
+}
+
+// Set adds the value to the flag values.
+func (f *configPortsFlag) Set(s string) error {
+	v, err := strconv.ParseUint(s, 10, 16)
+	if err != nil {
+		return err
+	}
+	*f = append(*f, uint16(v))
+	return nil
+}
+`,
+		},
+		{
+			name:    "Should return an error for unsupported types",
+			fields:  []StructField{*NewStructFieldWithTag("Ratio", NewType("float32"), flag("ratio", ""))},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewFlagRegistrar(NewStructWithFields("Config", tt.fields))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewFlagRegistrar() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if s := NewFile("test", got...).String(); s != tt.want {
+				t.Errorf("NewFlagRegistrar() = %v, want %v", s, tt.want)
+			}
+		})
+	}
+}
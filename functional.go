@@ -0,0 +1,205 @@
+package code
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dave/jennifer/jen"
+)
+
+// FunctionalOptions is used when you call NewFunctionalOptions, it is a handy way to allow multiple configurations
+// for the generated functional options.
+type FunctionalOptions func(o *functionalOptions)
+
+type functionalOptions struct {
+	name string
+}
+
+// NameFunctionalOption sets the name of the option type, the default name is Option (e.x ServerOption).
+// Only the option type is renamed, the With{Field} functions keep their names so two structures with the same
+// field names can not have their functional options in the same package.
+func NameFunctionalOption(name string) FunctionalOptions {
+	return func(o *functionalOptions) {
+		o.name = name
+	}
+}
+
+// NewFunctionalOptions creates the functional options of the structure.
+//
+// It returns the option type (e.x type Option func(*Config)), a With{Field} function for every field and
+// a New{Name}(opts ...Option) constructor that applies the options to a structure with the default values.
+// The default values are taken from the default tag of the fields (e.x `default:"8080"`) and are parsed the same way
+// as the values of NewEnvLoader: strings, bools, numbers, time.Duration (e.x `default:"5s"`), pointers to those types
+// and slices of those types that are read from comma separated values (e.x `default:"a,b"`).
+// An error is returned if a default value is invalid or the field type does not support default values.
+func NewFunctionalOptions(st *Struct, options ...FunctionalOptions) ([]Code, error) {
+	opts := &functionalOptions{
+		name: "Option",
+	}
+	for _, o := range options {
+		o(opts)
+	}
+	recv := receiverName(st.Name)
+	option := NewTypeDecl(
+		opts.name,
+		NewType("", FunctionTypeOption(NewFunctionType(
+			ParamsFunctionOption(*NewParameter("", NewType(st.Name, PointerTypeOption()))),
+		))),
+		Comment(opts.name+" configures a "+st.Name+"."),
+	)
+	code := []Code{option}
+	defaults := jen.Dict{}
+	var pointers []jen.Code
+	// o and opts are used by the constructor.
+	value := freeReceiverName(st.Name, "o", "opts")
+	for _, f := range st.Fields {
+		name := fieldName(f)
+		param := paramName(name)
+		fnRecv := recv
+		if fnRecv == param {
+			fnRecv = unexportedName(st.Name)
+		}
+		code = append(code, NewFunction(
+			"With"+exportedName(name),
+			ParamsFunctionOption(*NewParameter(param, f.Type.Clone())),
+			ResultsFunctionOption(*NewParameter("", NewType(opts.name))),
+			BodyFunctionOption(jen.Return(
+				jen.Func().Params(jen.Id(fnRecv).Op("*").Id(st.Name)).Block(
+					jen.Id(fnRecv).Dot(name).Op("=").Id(param),
+				),
+			)),
+			DocsFunctionOption(Comment("With"+exportedName(name)+" sets the "+name+" of the "+st.Name+".")),
+		))
+		if f.Tags == nil {
+			continue
+		}
+		tag, ok := (*f.Tags)["default"]
+		if !ok {
+			continue
+		}
+		elem := f.Type
+		if elem.Pointer {
+			elem = elem.Clone()
+			elem.Pointer = false
+		}
+		v, err := defaultValue(tag, elem)
+		if err != nil {
+			return nil, fmt.Errorf("the default value of the field %s: %w", name, err)
+		}
+		if !f.Type.Pointer {
+			defaults[jen.Id(name)] = v
+			continue
+		}
+		// pointers are set after the structure is created (e.x c.Timeout = new(time.Duration)).
+		pointers = append(
+			pointers,
+			jen.Id(value).Dot(name).Op("=").New(elem.Code()),
+			jen.Op("*").Id(value).Dot(name).Op("=").Add(v),
+		)
+	}
+	code = append(code, NewFunction(
+		"New"+exportedName(st.Name),
+		ParamsFunctionOption(*NewParameter("opts", NewType(opts.name, VariadicTypeOption()))),
+		ResultsFunctionOption(*NewParameter("", NewType(st.Name, PointerTypeOption()))),
+		BodyFunctionOption(append(
+			append([]jen.Code{jen.Id(value).Op(":=").Op("&").Id(st.Name).Values(defaults)}, pointers...),
+			jen.For(jen.List(jen.Id("_"), jen.Id("o")).Op(":=").Range().Id("opts")).Block(
+				jen.Id("o").Call(jen.Id(value)),
+			),
+			jen.Return(jen.Id(value)),
+		)...),
+		DocsFunctionOption(Comment("New"+exportedName(st.Name)+" creates a new "+st.Name+" with the default values and applies the options.")),
+	))
+	return code, nil
+}
+
+// defaultValue returns the go value of the default tag for the type,
+// slices are read from comma separated values (e.x a,b => []string{"a", "b"}).
+func defaultValue(tag string, tp Type) (jen.Code, error) {
+	if tp.ArrayType == nil || basicKind(tp) == "bytes" {
+		return basicDefaultValue(tag, tp)
+	}
+	var values []jen.Code
+	if tag != "" {
+		for _, s := range strings.Split(tag, ",") {
+			v, err := basicDefaultValue(strings.TrimSpace(s), *tp.ArrayType)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, v)
+		}
+	}
+	return tp.Code().Values(values...), nil
+}
+
+// basicDefaultValue returns the go value of the default tag for a string, bool, number, []byte or time.Duration type,
+// numbers are untyped constants so they can be used for every number type.
+func basicDefaultValue(tag string, tp Type) (jen.Code, error) {
+	switch {
+	case isDurationType(tp):
+		d, err := time.ParseDuration(tag)
+		if err != nil {
+			return nil, err
+		}
+		return durationValue(d), nil
+	case basicKind(tp) == "string":
+		return jen.Lit(tag), nil
+	case basicKind(tp) == "bytes":
+		return jen.Index().Byte().Call(jen.Lit(tag)), nil
+	case basicKind(tp) == "bool":
+		v, err := strconv.ParseBool(tag)
+		if err != nil {
+			return nil, err
+		}
+		return jen.Lit(v), nil
+	case basicKind(tp) == "int":
+		v, err := strconv.ParseInt(tag, 10, bitSize(tp))
+		if err != nil {
+			return nil, err
+		}
+		return jen.Op(strconv.FormatInt(v, 10)), nil
+	case basicKind(tp) == "uint":
+		v, err := strconv.ParseUint(tag, 10, bitSize(tp))
+		if err != nil {
+			return nil, err
+		}
+		return jen.Op(strconv.FormatUint(v, 10)), nil
+	case basicKind(tp) == "float":
+		v, err := strconv.ParseFloat(tag, bitSize(tp))
+		if err != nil {
+			return nil, err
+		}
+		return jen.Op(strconv.FormatFloat(v, 'g', -1, bitSize(tp))), nil
+	}
+	return nil, fmt.Errorf("the type %s is not supported", tp.String())
+}
+
+// durationValue returns the duration in the biggest unit it is a multiple of (e.x 90s => 90 * time.Second).
+func durationValue(d time.Duration) jen.Code {
+	units := []struct {
+		name  string
+		value time.Duration
+	}{
+		{"Hour", time.Hour},
+		{"Minute", time.Minute},
+		{"Second", time.Second},
+		{"Millisecond", time.Millisecond},
+		{"Microsecond", time.Microsecond},
+	}
+	if d == 0 {
+		return jen.Lit(0)
+	}
+	for _, u := range units {
+		if d%u.value == 0 {
+			return jen.Op(strconv.FormatInt(int64(d/u.value), 10)).Op("*").Qual("time", u.name)
+		}
+	}
+	return jen.Op(strconv.FormatInt(int64(d), 10))
+}
+
+func isStringType(tp Type) bool {
+	return tp.Qualifier == "string" && tp.Import == nil && !tp.Pointer &&
+		tp.RawType == nil && tp.ArrayType == nil && tp.MapType == nil && tp.Function == nil && tp.Struct == nil
+}
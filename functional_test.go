@@ -0,0 +1,243 @@
+package code
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNewFunctionalOptions(t *testing.T) {
+	config := NewStructWithFields("Config", []StructField{
+		*NewStructFieldWithTag("Addr", NewType("string"), NewFieldTags("default", ":8080")),
+		*NewStructFieldWithTag("MaxConns", NewType("int"), NewFieldTags("default", "20")),
+		*NewStructField("C", NewType("Client", ImportTypeOption(*NewImport("", "net/http")), PointerTypeOption())),
+	})
+	tests := []struct {
+		name    string
+		st      *Struct
+		options []FunctionalOptions
+		want    string
+	}{
+		{
+			name: "Should create the functional options",
+			st:   config,
+			want: `package test
+
+import "net/http"
+
+// Option configures a Config.
+type Option func(*Config)
+
+// WithAddr sets the Addr of the Config.
+func WithAddr(addr string) Option {
+	return func(c *Config) {
+		c.Addr = addr
+	}
+}
+
+// WithMaxConns sets the MaxConns of the Config.
+func WithMaxConns(maxConns int) Option {
+	return func(c *Config) {
+		c.MaxConns = maxConns
+	}
+}
+
+// WithC sets the C of the Config.
+func WithC(c *http.Client) Option {
+	return func(config *Config) {
+		config.C = c
+	}
+}
+
+// NewConfig creates a new Config with the default values and applies the options.
+func NewConfig(opts ...Option) *Config {
+	c := &Config{
+		Addr:     ":8080",
+		MaxConns: 20,
+	}
+	for _, o := range opts {
+		o(c)
+	}
+	return c
+}
+`,
+		},
+		{
+			name:    "Should use the option type name",
+			st:      config,
+			options: []FunctionalOptions{NameFunctionalOption("ConfigOption")},
+			want: `package test
+
+import "net/http"
+
+// ConfigOption configures a Config.
+type ConfigOption func(*Config)
+
+// WithAddr sets the Addr of the Config.
+func WithAddr(addr string) ConfigOption {
+	return func(c *Config) {
+		c.Addr = addr
+	}
+}
+
+// WithMaxConns sets the MaxConns of the Config.
+func WithMaxConns(maxConns int) ConfigOption {
+	return func(c *Config) {
+		c.MaxConns = maxConns
+	}
+}
+
+// WithC sets the C of the Config.
+func WithC(c *http.Client) ConfigOption {
+	return func(config *Config) {
+		config.C = c
+	}
+}
+
+// NewConfig creates a new Config with the default values and applies the options.
+func NewConfig(opts ...ConfigOption) *Config {
+	c := &Config{
+		Addr:     ":8080",
+		MaxConns: 20,
+	}
+	for _, o := range opts {
+		o(c)
+	}
+	return c
+}
+`,
+		},
+		{
+			name: "Should not use the option variables in the constructor",
+			st:   NewStructWithFields("Order", []StructField{*NewStructFieldWithTag("ID", NewType("int"), NewFieldTags("default", "1"))}),
+			want: `package test
+
+// Option configures a Order.
+type Option func(*Order)
+
+// WithID sets the ID of the Order.
+func WithID(id int) Option {
+	return func(o *Order) {
+		o.ID = id
+	}
+}
+
+// NewOrder creates a new Order with the default values and applies the options.
+func NewOrder(opts ...Option) *Order {
+	order := &Order{ID: 1}
+	for _, o := range opts {
+		o(order)
+	}
+	return order
+}
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, err := NewFunctionalOptions(tt.st, tt.options...)
+			if err != nil {
+				t.Fatalf("NewFunctionalOptions() error = %v", err)
+			}
+			if got := NewFile("test", code...).String(); got != tt.want {
+				t.Errorf("NewFunctionalOptions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestNewFunctionalOptions_Defaults compiles the constructor with the default values of every supported type.
+func TestNewFunctionalOptions_Defaults(t *testing.T) {
+	duration := NewType("Duration", ImportTypeOption(*NewImport("", "time")))
+	server := NewStructWithFields("Server", []StructField{
+		*NewStructFieldWithTag("Timeout", duration, NewFieldTags("default", "1m30s")),
+		*NewStructFieldWithTag("Retry", duration, NewFieldTags("default", "1500us")),
+		*NewStructFieldWithTag("Hosts", NewType("", ArrayTypeOption(NewType("string"))), NewFieldTags("default", "a, b")),
+		*NewStructFieldWithTag("Ports", NewType("", ArrayTypeOption(NewType("uint16"))), NewFieldTags("default", "80,443")),
+		*NewStructFieldWithTag("Ratio", NewType("float64", PointerTypeOption()), NewFieldTags("default", "0.5")),
+		*NewStructFieldWithTag("Debug", NewType("bool"), NewFieldTags("default", "true")),
+		*NewStructFieldWithTag("Key", NewType("", ArrayTypeOption(NewType("byte"))), NewFieldTags("default", "secret")),
+		*NewStructFieldWithTag("Offset", NewType("int8"), NewFieldTags("default", "-3")),
+	})
+	code, err := NewFunctionalOptions(server)
+	if err != nil {
+		t.Fatalf("NewFunctionalOptions() error = %v", err)
+	}
+	want := `	s := &Server{
+		Debug:   true,
+		Hosts:   []string{"a", "b"},
+		Key:     []byte("secret"),
+		Offset:  -3,
+		Ports:   []uint16{80, 443},
+		Retry:   1500 * time.Microsecond,
+		Timeout: 90 * time.Second,
+	}
+	s.Ratio = new(float64)
+	*s.Ratio = 0.5
+`
+	got := NewFile("options", code...).String()
+	if !strings.Contains(got, want) {
+		t.Errorf("NewFunctionalOptions() = %v, want it to contain %v", got, want)
+	}
+	runGeneratedTests(t, "options", map[string]string{
+		"gen.go":      NewFile("options", append([]Code{server}, code...)...).String(),
+		"gen_test.go": functionalDefaultsTest,
+	})
+}
+
+const functionalDefaultsTest = `package options
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDefaults(t *testing.T) {
+	ratio := 0.5
+	want := &Server{
+		Timeout: 90 * time.Second, Retry: 1500 * time.Microsecond, Hosts: []string{"a", "b"}, Ports: []uint16{80, 443},
+		Ratio: &ratio, Debug: true, Key: []byte("secret"), Offset: -3,
+	}
+	if got := NewServer(); !reflect.DeepEqual(got, want) {
+		t.Errorf("NewServer() = %+v, want %+v", got, want)
+	}
+	if got := NewServer(WithDebug(false)); got.Debug {
+		t.Error("NewServer() did not apply the options")
+	}
+}
+`
+
+func TestNewFunctionalOptions_InvalidDefaults(t *testing.T) {
+	tests := []struct {
+		name  string
+		field StructField
+	}{
+		{
+			name:  "Should return an error for an invalid number",
+			field: *NewStructFieldWithTag("MaxConns", NewType("int"), NewFieldTags("default", "10 * 2")),
+		},
+		{
+			name:  "Should return an error for a number out of range",
+			field: *NewStructFieldWithTag("Small", NewType("uint8"), NewFieldTags("default", "256")),
+		},
+		{
+			name:  "Should return an error for an invalid duration",
+			field: *NewStructFieldWithTag("Timeout", NewType("Duration", ImportTypeOption(*NewImport("", "time"))), NewFieldTags("default", "5")),
+		},
+		{
+			name:  "Should return an error for an invalid slice value",
+			field: *NewStructFieldWithTag("Ports", NewType("", ArrayTypeOption(NewType("int"))), NewFieldTags("default", "80,x")),
+		},
+		{
+			name:  "Should return an error for an unsupported type",
+			field: *NewStructFieldWithTag("Client", NewType("Client", ImportTypeOption(*NewImport("", "net/http"))), NewFieldTags("default", "x")),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewFunctionalOptions(NewStructWithFields("Config", []StructField{tt.field})); err == nil {
+				t.Error("NewFunctionalOptions() expected an error")
+			}
+		})
+	}
+}
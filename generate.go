@@ -0,0 +1,322 @@
+package code
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/dave/jennifer/jen"
+)
+
+// zeroValue returns the zero value literal of the type,
+// it returns false if the zero value can not be known from the type alone (e.x a named struct or an imported type).
+func zeroValue(tp Type) (*jen.Statement, bool) {
+	if tp.RawType != nil {
+		return nil, false
+	}
+	if tp.Pointer || tp.Variadic || tp.ArrayType != nil || tp.MapType != nil || tp.Function != nil {
+		return jen.Nil(), true
+	}
+	if tp.Struct != nil {
+		return tp.Code().Values(), true
+	}
+	if tp.Import != nil {
+		return nil, false
+	}
+	switch tp.Qualifier {
+	case "string":
+		return jen.Lit(""), true
+	case "bool":
+		return jen.False(), true
+	case "int", "int8", "int16", "int32", "int64",
+		"uint", "uint8", "uint16", "uint32", "uint64", "uintptr",
+		"byte", "rune", "float32", "float64", "complex64", "complex128":
+		return jen.Lit(0), true
+	case "error", "any", "interface{}":
+		return jen.Nil(), true
+	}
+	return nil, false
+}
+
+// zeroReturn returns the statements that return the zero values of the results,
+// results that do not have a known zero value are declared as variables first (e.x r0) with names
+// that are not used by the parameters or the results.
+// If errorValue is not nil it is returned for the last result if it is of type error.
+func zeroReturn(params, results []Parameter, errorValue *jen.Statement) []jen.Code {
+	used := append(signatureNames(params), signatureNames(results)...)
+	var decls []jen.Code
+	var values []jen.Code
+	for i, r := range results {
+		if errorValue != nil && i == len(results)-1 && isErrorType(r.Type) {
+			values = append(values, errorValue)
+			continue
+		}
+		if r.Name != "" && r.Name != "_" {
+			values = append(values, jen.Id(r.Name))
+			continue
+		}
+		if v, ok := zeroValue(r.Type); ok {
+			values = append(values, v)
+			continue
+		}
+		name := unusedName("r"+strconv.Itoa(i), used)
+		used = append(used, name)
+		decls = append(decls, jen.Var().Id(name).Add(r.Type.Code()))
+		values = append(values, jen.Id(name))
+	}
+	return append(decls, jen.Return(values...))
+}
+
+func isErrorType(tp Type) bool {
+	return tp.RawType == nil && tp.Import == nil && !tp.Pointer && tp.ArrayType == nil &&
+		tp.MapType == nil && tp.Function == nil && tp.Struct == nil && tp.Qualifier == "error"
+}
+
+// namedParams returns a copy of the parameters where every unnamed parameter gets the name prefix{index}
+// (e.x arg0, arg1), a generated name that is used by another parameter gets a number suffix (e.x arg01).
+func namedParams(params []Parameter, prefix string) []Parameter {
+	named := cloneParams(params)
+	used := signatureNames(named)
+	for i := range named {
+		if named[i].Name == "" || named[i].Name == "_" {
+			named[i].Name = unusedName(prefix+strconv.Itoa(i), used)
+			used = append(used, named[i].Name)
+		}
+	}
+	return named
+}
+
+// callArgs returns the parameters as call arguments, variadic parameters are expanded (e.x args...).
+func callArgs(params []Parameter) []jen.Code {
+	var args []jen.Code
+	for _, p := range params {
+		if p.Type.Variadic {
+			args = append(args, jen.Id(p.Name).Op("..."))
+			continue
+		}
+		args = append(args, jen.Id(p.Name))
+	}
+	return args
+}
+
+// freeName returns the name if no parameter or result of the methods uses it,
+// otherwise the alternative or the alternative with the first free number (e.x mock1).
+func freeName(name, alternative string, methods []InterfaceMethod) string {
+	var used []string
+	for _, m := range methods {
+		for _, p := range append(append([]Parameter{}, m.Params...), m.Results...) {
+			used = append(used, p.Name)
+		}
+	}
+	if !contains(used, name) {
+		return name
+	}
+	return unusedName(alternative, used)
+}
+
+// unusedName returns the name if it is not in the used names, otherwise the name with the first free number (e.x begin1).
+func unusedName(name string, used []string) string {
+	free := name
+	for i := 1; contains(used, free); i++ {
+		free = name + strconv.Itoa(i)
+	}
+	return free
+}
+
+func contains(list []string, s string) bool {
+	for _, l := range list {
+		if l == s {
+			return true
+		}
+	}
+	return false
+}
+
+// exportedName returns the name with the first letter in upper case (e.x id => Id).
+func exportedName(name string) string {
+	if name == "" {
+		return name
+	}
+	return strings.ToUpper(name[:1]) + name[1:]
+}
+
+// unexportedName returns the name with the first letter in lower case (e.x Get => get).
+func unexportedName(name string) string {
+	if name == "" {
+		return name
+	}
+	return strings.ToLower(name[:1]) + name[1:]
+}
+
+// namedResults returns a copy of the results where every unnamed result gets a name that is not used by the parameters
+// or the other results, a last error result is named err and the other results are named r{index}
+// (e.x a result named err1 if a parameter is named err).
+func namedResults(params, results []Parameter) []Parameter {
+	named := cloneParams(results)
+	used := append(signatureNames(params), signatureNames(named)...)
+	for i := range named {
+		if named[i].Name != "" && named[i].Name != "_" {
+			continue
+		}
+		name := "r" + strconv.Itoa(i)
+		if i == len(named)-1 && isErrorType(named[i].Type) {
+			name = "err"
+		}
+		named[i].Name = unusedName(name, used)
+		used = append(used, named[i].Name)
+	}
+	return named
+}
+
+// signatureNames returns the names of the parameters, unnamed and blank parameters are skipped.
+func signatureNames(params []Parameter) []string {
+	var names []string
+	for _, p := range params {
+		if p.Name != "" && p.Name != "_" {
+			names = append(names, p.Name)
+		}
+	}
+	return names
+}
+
+// resultValues returns the results as a list of identifiers.
+func resultValues(results []Parameter) []jen.Code {
+	var values []jen.Code
+	for _, r := range results {
+		values = append(values, jen.Id(r.Name))
+	}
+	return values
+}
+
+// paramName returns the lower camel case name used for parameters and variables (e.x ID => id, HTTPClient => httpClient),
+// names that are go keywords get an underscore suffix (e.x Type => type_).
+func paramName(name string) string {
+	runes := []rune(name)
+	upper := 0
+	for upper < len(runes) && unicode.IsUpper(runes[upper]) {
+		upper++
+	}
+	if upper > 1 && upper < len(runes) {
+		// the last upper case letter is the start of the next word (e.x HTTPClient => http Client).
+		upper--
+	}
+	for i := 0; i < upper; i++ {
+		runes[i] = unicode.ToLower(runes[i])
+	}
+	s := string(runes)
+	if jen.IsReservedWord(s) {
+		s += "_"
+	}
+	return s
+}
+
+// freeReceiverName returns a receiver name for the type that is not one of the names used by the generated code,
+// a used name also excludes the loop variables of the nested levels (e.x i also excludes i1 and i2).
+// The candidates are the default receiver name, the parameter name of the type and recv followed by underscores (e.x recv_).
+func freeReceiverName(typeName string, used ...string) string {
+	isUsed := func(name string) bool {
+		for _, u := range used {
+			if !strings.HasPrefix(name, u) {
+				continue
+			}
+			if _, err := strconv.Atoi(name[len(u):]); name == u || err == nil {
+				return true
+			}
+		}
+		return false
+	}
+	for _, name := range []string{receiverName(typeName), paramName(typeName)} {
+		if !isUsed(name) {
+			return name
+		}
+	}
+	name := "recv"
+	for isUsed(name) {
+		name += "_"
+	}
+	return name
+}
+
+// fieldName returns the name of the structure field, embedded fields are named after their type.
+func fieldName(f StructField) string {
+	if f.Name != "" {
+		return f.Name
+	}
+	return f.Type.Qualifier
+}
+
+// tagOptions returns the comma separated values of the field tag key (e.x `new:"required,default"` => required, default).
+func tagOptions(f StructField, key string) []string {
+	if f.Tags == nil {
+		return nil
+	}
+	value, ok := (*f.Tags)[key]
+	if !ok || value == "" {
+		return nil
+	}
+	var options []string
+	for _, v := range strings.Split(value, ",") {
+		options = append(options, strings.TrimSpace(v))
+	}
+	return options
+}
+
+// hasTagOption returns true if the field tag key contains the option.
+func hasTagOption(f StructField, key, option string) bool {
+	return contains(tagOptions(f, key), option)
+}
+
+// isExported returns true if the name starts with an upper case letter.
+func isExported(name string) bool {
+	for _, r := range name {
+		return unicode.IsUpper(r)
+	}
+	return false
+}
+
+// basicKind returns the kind of the builtin type (string, bool, int, uint, float or bytes for []byte),
+// it returns an empty string for the other types.
+func basicKind(tp Type) string {
+	if tp.ArrayType != nil && !tp.Pointer && tp.ArrayType.Qualifier == "byte" && !tp.ArrayType.Pointer &&
+		tp.ArrayType.Import == nil && tp.ArrayType.ArrayType == nil && tp.ArrayType.MapType == nil {
+		return "bytes"
+	}
+	if tp.Pointer || tp.Import != nil || tp.RawType != nil || tp.ArrayType != nil || tp.MapType != nil ||
+		tp.Function != nil || tp.Struct != nil {
+		return ""
+	}
+	switch tp.Qualifier {
+	case "string", "bool":
+		return tp.Qualifier
+	case "int", "int8", "int16", "int32", "int64", "rune":
+		return "int"
+	case "uint", "uint8", "uint16", "uint32", "uint64", "uintptr", "byte":
+		return "uint"
+	case "float32", "float64":
+		return "float"
+	}
+	return ""
+}
+
+// bitSize returns the bit size of the number type used by strconv.
+func bitSize(tp Type) int {
+	switch tp.Qualifier {
+	case "int8", "uint8", "byte":
+		return 8
+	case "int16", "uint16":
+		return 16
+	case "int32", "uint32", "rune", "float32":
+		return 32
+	case "int64", "uint64", "uintptr", "float64":
+		return 64
+	}
+	return 0
+}
+
+// convert converts the value of the type to the type name if they are not the same (e.x int64(v)).
+func convert(name string, tp Type, value jen.Code) jen.Code {
+	if tp.Qualifier == name {
+		return value
+	}
+	return jen.Id(name).Call(value)
+}
@@ -0,0 +1,39 @@
+package code
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+// runGeneratedTests writes the files in a temporary module with a go.mod for the module name and runs go test in it,
+// the test is skipped in short mode or if go is not installed.
+func runGeneratedTests(t *testing.T, module string, files map[string]string) {
+	t.Helper()
+	if testing.Short() {
+		t.Skip("skipping the compilation of the generated code in short mode")
+	}
+	goBin, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go is not installed")
+	}
+	dir, err := ioutil.TempDir("", module)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	files["go.mod"] = "module " + module + "\n\ngo 1.18\n"
+	for name, content := range files {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	cmd := exec.Command(goBin, "test", "./...")
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GOFLAGS=", "GO111MODULE=on")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("go test of the generated code failed: %v\n%s", err, out)
+	}
+}
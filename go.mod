@@ -2,7 +2,4 @@ module github.com/go-services/code
 
 go 1.12
 
-require (
-	github.com/dave/jennifer v1.7.0
-	github.com/pkg/errors v0.9.1
-)
+require github.com/dave/jennifer v1.7.0
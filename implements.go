@@ -0,0 +1,127 @@
+package code
+
+import (
+	"strings"
+)
+
+// ImplementsOptions is used when you call Implements, it is a handy way to allow multiple configurations
+// for the check.
+type ImplementsOptions func(o *implementsOptions)
+
+type implementsOptions struct {
+	value bool
+}
+
+// ValueImplementsOption checks the method set of the value type (e.x MyStruct instead of *MyStruct),
+// methods with a pointer receiver are not part of the value method set so they are reported as missing.
+func ValueImplementsOption() ImplementsOptions {
+	return func(o *implementsOptions) {
+		o.value = true
+	}
+}
+
+// MethodMismatch represents an interface method that the type has but with a different signature.
+type MethodMismatch struct {
+	// Want is the interface method.
+	Want InterfaceMethod
+
+	// Have is the method of the type.
+	Have *Function
+}
+
+// NotImplementedError is returned by Implements when the type does not implement the interface.
+type NotImplementedError struct {
+	// Type is the name of the type that was checked.
+	Type string
+
+	// Interface is the name of the interface.
+	Interface string
+
+	// Missing are the interface methods the type does not have.
+	Missing []InterfaceMethod
+
+	// Mismatched are the interface methods the type has with a different signature.
+	Mismatched []MethodMismatch
+}
+
+// Error returns the error message listing all missing and mismatched methods.
+func (e *NotImplementedError) Error() string {
+	var problems []string
+	for _, m := range e.Missing {
+		problems = append(problems, "missing method "+m.Name)
+	}
+	for _, m := range e.Mismatched {
+		problems = append(
+			problems,
+			"wrong signature for method "+m.Want.Name+
+				", have "+methodSignature(m.Have)+
+				", want "+methodSignature((*Function)(&m.Want)),
+		)
+	}
+	return e.Type + " does not implement " + e.Interface + ": " + strings.Join(problems, "; ")
+}
+
+// Implements checks if the type implements the interface.
+//
+// By default the method set of the pointer type is checked (e.x *MyStruct), parameters and results are
+// compared by their types, parameter names and import aliases are ignored.
+// If the type does not implement the interface a *NotImplementedError is returned
+// listing all the missing methods and the methods with a different signature.
+func Implements(typ MethodSet, iface *Interface, options ...ImplementsOptions) error {
+	opts := &implementsOptions{}
+	for _, o := range options {
+		o(opts)
+	}
+	methods := map[string]*Function{}
+	for _, m := range typ.Methods() {
+		if opts.value && m.Recv != nil && isPointerReceiver(m.Recv.Type) {
+			continue
+		}
+		methods[m.Name] = m
+	}
+	identity := &equalOptions{identity: true}
+	err := &NotImplementedError{
+		Type:      methodSetName(typ),
+		Interface: iface.Name,
+	}
+	for _, want := range iface.Methods {
+		have, ok := methods[want.Name]
+		if !ok {
+			err.Missing = append(err.Missing, want)
+			continue
+		}
+		if !identity.paramsEqual(want.Params, have.Params) || !identity.paramsEqual(want.Results, have.Results) {
+			err.Mismatched = append(err.Mismatched, MethodMismatch{Want: want, Have: have})
+		}
+	}
+	if err.Missing == nil && err.Mismatched == nil {
+		return nil
+	}
+	return err
+}
+
+func methodSetName(typ MethodSet) string {
+	if c, ok := typ.(Code); ok {
+		if name := codeName(c); name != "" {
+			return name
+		}
+	}
+	return "type"
+}
+
+func isPointerReceiver(tp Type) bool {
+	if tp.RawType != nil {
+		return strings.HasPrefix(tp.String(), "*")
+	}
+	return tp.Pointer
+}
+
+// methodSignature returns the method signature without docs and receiver (e.x Get(id string) error).
+func methodSignature(fn *Function) string {
+	m := InterfaceMethod{
+		Name:    fn.Name,
+		Params:  fn.Params,
+		Results: fn.Results,
+	}
+	return m.String()
+}
@@ -0,0 +1,148 @@
+package code
+
+import (
+	"testing"
+)
+
+func TestImplements(t *testing.T) {
+	ctx := NewType("Context", ImportTypeOption(*NewImport("", "context")))
+	ctxAlias := NewType("Context", ImportTypeOption(*NewImport("ctx", "context")))
+	iface := NewInterface("Service", []InterfaceMethod{
+		NewInterfaceMethod("Get",
+			ParamsFunctionOption(*NewParameter("ctx", ctx), *NewParameter("id", NewType("string"))),
+			ResultsFunctionOption(*NewParameter("", NewType("User", PointerTypeOption())), *NewParameter("", NewType("error"))),
+		),
+		NewInterfaceMethod("List",
+			ParamsFunctionOption(*NewParameter("ids", NewType("string", VariadicTypeOption()))),
+			ResultsFunctionOption(*NewParameter("", NewType("", ArrayTypeOption(NewType("User"))))),
+		),
+	})
+	get := func() *Function {
+		return NewFunction("Get",
+			ParamsFunctionOption(*NewParameter("c", ctxAlias), *NewParameter("userID", NewType("string"))),
+			ResultsFunctionOption(*NewParameter("u", NewType("User", PointerTypeOption())), *NewParameter("err", NewType("error"))),
+		)
+	}
+	list := func() *Function {
+		return NewFunction("List",
+			ParamsFunctionOption(*NewParameter("", NewType("string", VariadicTypeOption()))),
+			ResultsFunctionOption(*NewParameter("", NewType("", ArrayTypeOption(NewType("User"))))),
+		)
+	}
+	tests := []struct {
+		name    string
+		typ     func() MethodSet
+		options []ImplementsOptions
+		wantErr string
+	}{
+		{
+			name: "Should implement the interface ignoring parameter names and import aliases",
+			typ: func() MethodSet {
+				s := NewStruct("Impl")
+				s.AddMethod(get())
+				s.AddMethod(list())
+				s.AddMethod(NewFunction("Extra"))
+				return s
+			},
+		},
+		{
+			name: "Should report missing methods",
+			typ: func() MethodSet {
+				s := NewStruct("Impl")
+				s.AddMethod(get())
+				return s
+			},
+			wantErr: "Impl does not implement Service: missing method List",
+		},
+		{
+			name: "Should report mismatched methods",
+			typ: func() MethodSet {
+				td := NewTypeDecl("Impl", NewType("int"))
+				td.AddMethod(get())
+				td.AddMethod(NewFunction("List",
+					ParamsFunctionOption(*NewParameter("ids", NewType("", ArrayTypeOption(NewType("string"))))),
+					ResultsFunctionOption(*NewParameter("", NewType("", ArrayTypeOption(NewType("User"))))),
+				))
+				return td
+			},
+			wantErr: "Impl does not implement Service: wrong signature for method List, have List(ids []string) []User, want List(ids ...string) []User",
+		},
+		{
+			name: "Should report different imports as mismatched",
+			typ: func() MethodSet {
+				s := NewStruct("Impl")
+				s.AddMethod(NewFunction("Get",
+					ParamsFunctionOption(
+						*NewParameter("ctx", NewType("Context", ImportTypeOption(*NewImport("", "golang.org/x/net/context")))),
+						*NewParameter("id", NewType("string")),
+					),
+					ResultsFunctionOption(*NewParameter("", NewType("User", PointerTypeOption())), *NewParameter("", NewType("error"))),
+				))
+				s.AddMethod(list())
+				return s
+			},
+			wantErr: "Impl does not implement Service: wrong signature for method Get, have Get(ctx context.Context, id string) (*User, error), want Get(ctx context.Context, id string) (*User, error)",
+		},
+		{
+			name: "Should ignore pointer receiver methods for the value method set",
+			typ: func() MethodSet {
+				s := NewStruct("Impl")
+				s.AddMethod(get())
+				s.AddMethod(list(), ValueReceiverMethodOption())
+				return s
+			},
+			options: []ImplementsOptions{ValueImplementsOption()},
+			wantErr: "Impl does not implement Service: missing method Get",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Implements(tt.typ(), iface, tt.options...)
+			if (err != nil) != (tt.wantErr != "") {
+				t.Fatalf("Implements() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil {
+				return
+			}
+			if _, ok := err.(*NotImplementedError); !ok {
+				t.Errorf("Implements() error = %#v, want *NotImplementedError", err)
+			}
+			if err.Error() != tt.wantErr {
+				t.Errorf("Implements() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestImplements_StructFields(t *testing.T) {
+	structType := func(name string) Type {
+		return NewType("", StructTypeOption(*NewStructType(*NewStructField(name, NewType("int")))))
+	}
+	iface := NewInterface("Service", []InterfaceMethod{
+		NewInterfaceMethod("Set", ParamsFunctionOption(*NewParameter("options", structType("A")))),
+	})
+	tests := []struct {
+		name    string
+		field   string
+		wantErr bool
+	}{
+		{
+			name:  "Should implement the interface with the same structure type",
+			field: "A",
+		},
+		{
+			name:    "Should report structure types with other field names as mismatched",
+			field:   "B",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewStruct("Impl")
+			s.AddMethod(NewFunction("Set", ParamsFunctionOption(*NewParameter("o", structType(tt.field)))))
+			if err := Implements(s, iface); (err != nil) != tt.wantErr {
+				t.Errorf("Implements() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,730 @@
+package code
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/dave/jennifer/jen"
+)
+
+// JSONOptions is used when you call AddJSON, it is a handy way to allow multiple configurations
+// for the generated JSON methods.
+type JSONOptions func(o *jsonOptions)
+
+type jsonOptions struct {
+	types []string
+	err   bool
+}
+
+// TypesJSONOption sets the names of the package types that have JSON methods generated by AddJSON,
+// fields of those types are encoded and decoded with the generated methods.
+func TypesJSONOption(names ...string) JSONOptions {
+	return func(o *jsonOptions) {
+		o.types = append(o.types, names...)
+	}
+}
+
+// AddJSON adds the MarshalJSON and UnmarshalJSON methods to the structure and returns the added methods.
+//
+// The methods write and read the JSON directly without encoding/json reflection, they use the JSON helpers
+// returned by NewJSONRuntime that must be generated once in the same package.
+// The fields are encoded like encoding/json does following their json tags (e.x `json:"name,omitempty"`),
+// `json:"-"` skips the field, the string option encodes numbers and bools as JSON strings and
+// omitempty does not omit time.Time fields because encoding/json never omits structures.
+// The supported field types are strings, bools, numbers, []byte (base64), time.Time, the structure type,
+// the types set with TypesJSONOption and pointers, slices and maps with string keys of those types.
+// Object keys are matched exactly and unknown keys are skipped.
+// An error is returned if a field is embedded or has a type that is not supported.
+func AddJSON(st *Struct, options ...JSONOptions) ([]*Function, error) {
+	opts := &jsonOptions{
+		types: []string{st.Name},
+	}
+	for _, o := range options {
+		o(opts)
+	}
+	recv := freeReceiverName(st.Name, "l", "buf", "err", "key", "start", "data", "i", "k", "keys", "v")
+	var write, cases []jen.Code
+	for _, f := range st.Fields {
+		if f.Name == "" {
+			return nil, fmt.Errorf("the embedded field %s is not supported", fieldName(f))
+		}
+		tag := tagOptions(f, "json")
+		if !isExported(f.Name) || (len(tag) == 1 && tag[0] == "-") {
+			continue
+		}
+		key := f.Name
+		if len(tag) > 0 && tag[0] != "" {
+			key = tag[0]
+		}
+		quoted := len(tag) > 1 && contains(tag[1:], "string")
+		value := func() *jen.Statement { return jen.Id(recv).Dot(f.Name) }
+		keyJSON, _ := json.Marshal(key)
+
+		code, err := opts.encode(value, f.Type, quoted, 0)
+		if err != nil {
+			return nil, fmt.Errorf("the field %s: %w", f.Name, err)
+		}
+		code = append([]jen.Code{jen.Id("buf").Op("=").Append(jen.Id("buf"), jen.Lit(","+string(keyJSON)+":").Op("..."))}, code...)
+		// structures (e.x time.Time) are never omitted by encoding/json.
+		if len(tag) > 1 && contains(tag[1:], "omitempty") && !isTimeType(f.Type) {
+			if cond, ok := zeroCondition(f.Type, value, true); ok {
+				code = []jen.Code{jen.If(cond).Block(code...)}
+			}
+		}
+		write = append(write, code...)
+
+		read, err := opts.decode(value, f.Type, quoted, 0)
+		if err != nil {
+			return nil, fmt.Errorf("the field %s: %w", f.Name, err)
+		}
+		cases = append(cases, jen.Case(jen.Lit(key)).Block(read...))
+	}
+
+	appendBody := []jen.Code{}
+	if opts.err {
+		appendBody = append(appendBody, jen.Var().Err().Error())
+	}
+	appendBody = append(appendBody, jen.Id("start").Op(":=").Len(jen.Id("buf")))
+	appendBody = append(appendBody, write...)
+	appendBody = append(
+		appendBody,
+		// every field starts with a comma, the first one is replaced by the opening brace.
+		jen.If(jen.Len(jen.Id("buf")).Op("==").Id("start")).Block(
+			jen.Id("buf").Op("=").Append(jen.Id("buf"), jen.LitRune('{')),
+		).Else().Block(
+			jen.Id("buf").Index(jen.Id("start")).Op("=").LitRune('{'),
+		),
+		jen.Return(jen.Append(jen.Id("buf"), jen.LitRune('}')), jen.Nil()),
+	)
+	cases = append(cases, jen.Default().Block(jen.Id("l").Dot("raw").Call()))
+
+	bytesResult := []Parameter{*NewParameter("", NewType("", ArrayTypeOption(NewType("byte")))), *NewParameter("", NewType("error"))}
+	lexer := *NewParameter("l", NewType("jsonLexer", PointerTypeOption()))
+	methods := []*Function{
+		NewFunction(
+			"MarshalJSON",
+			ResultsFunctionOption(cloneParams(bytesResult)...),
+			BodyFunctionOption(jen.Return(jen.Id(recv).Dot("appendJSON").Call(jen.Nil()))),
+			DocsFunctionOption(Comment("MarshalJSON returns the JSON encoding of the "+st.Name+".")),
+		),
+		NewFunction(
+			"appendJSON",
+			ParamsFunctionOption(*NewParameter("buf", NewType("", ArrayTypeOption(NewType("byte"))))),
+			ResultsFunctionOption(cloneParams(bytesResult)...),
+			BodyFunctionOption(appendBody...),
+			DocsFunctionOption(Comment("appendJSON appends the JSON encoding of the "+st.Name+" to buf.")),
+		),
+		NewFunction(
+			"UnmarshalJSON",
+			ParamsFunctionOption(*NewParameter("data", NewType("", ArrayTypeOption(NewType("byte"))))),
+			ResultsFunctionOption(*NewParameter("", NewType("error"))),
+			BodyFunctionOption(
+				jen.Id("l").Op(":=").Op("&").Id("jsonLexer").Values(jen.Dict{jen.Id("data"): jen.Id("data")}),
+				jen.Id(recv).Dot("readJSON").Call(jen.Id("l")),
+				jen.Id("l").Dot("eof").Call(),
+				jen.Return(jen.Id("l").Dot("err")),
+			),
+			DocsFunctionOption(Comment("UnmarshalJSON sets the "+st.Name+" from its JSON encoding.")),
+		),
+		NewFunction(
+			"readJSON",
+			ParamsFunctionOption(lexer),
+			BodyFunctionOption(
+				jen.If(jen.Id("l").Dot("null").Call()).Block(jen.Return()),
+				jen.Id("l").Dot("expect").Call(jen.LitRune('{')),
+				jen.For(jen.Op("!").Id("l").Dot("end").Call(jen.LitRune('}'))).Block(
+					jen.Id("key").Op(":=").Id("l").Dot("str").Call(),
+					jen.Id("l").Dot("expect").Call(jen.LitRune(':')),
+					jen.Switch(jen.Id("key")).Block(cases...),
+					jen.Id("l").Dot("comma").Call(jen.LitRune('}')),
+				),
+			),
+			DocsFunctionOption(Comment("readJSON reads the "+st.Name+" from the lexer.")),
+		),
+	}
+	st.AddMethod(methods[0], ValueReceiverMethodOption(), ReceiverNameMethodOption(recv))
+	st.AddMethod(methods[1], ValueReceiverMethodOption(), ReceiverNameMethodOption(recv))
+	st.AddMethod(methods[2], ReceiverNameMethodOption(recv))
+	st.AddMethod(methods[3], ReceiverNameMethodOption(recv))
+	return methods, nil
+}
+
+// encode returns the code that appends the JSON encoding of the value to buf.
+func (o *jsonOptions) encode(value func() *jen.Statement, tp Type, quoted bool, depth int) ([]jen.Code, error) {
+	check := func(call jen.Code) []jen.Code {
+		o.err = true
+		return []jen.Code{
+			jen.List(jen.Id("buf"), jen.Err()).Op("=").Add(call),
+			jen.If(jen.Err().Op("!=").Nil()).Block(jen.Return(jen.Nil(), jen.Err())),
+		}
+	}
+	appendBuf := func(values ...jen.Code) jen.Code {
+		return jen.Id("buf").Op("=").Append(append([]jen.Code{jen.Id("buf")}, values...)...)
+	}
+	quote := func(code []jen.Code) []jen.Code {
+		if !quoted {
+			return code
+		}
+		return append(append([]jen.Code{appendBuf(jen.LitRune('"'))}, code...), appendBuf(jen.LitRune('"')))
+	}
+	null := func(code ...jen.Code) []jen.Code {
+		return []jen.Code{jen.If(value().Op("==").Nil()).Block(
+			appendBuf(jen.Lit("null").Op("...")),
+		).Else().Block(code...)}
+	}
+	if quoted && !contains([]string{"bool", "int", "uint", "float"}, basicKind(tp)) {
+		return nil, fmt.Errorf("the string option is only supported for bools and numbers")
+	}
+	switch {
+	case tp.Pointer:
+		elem := tp.Clone()
+		elem.Pointer = false
+		target := func() *jen.Statement { return jen.Op("*").Add(value()) }
+		if o.generated(elem) {
+			target = value
+		} else if elem.ArrayType != nil || elem.MapType != nil {
+			target = func() *jen.Statement { return jen.Parens(jen.Op("*").Add(value())) }
+		}
+		code, err := o.encode(target, elem, quoted, depth)
+		if err != nil {
+			return nil, err
+		}
+		return null(code...), nil
+	case o.generated(tp):
+		return check(value().Dot("appendJSON").Call(jen.Id("buf"))), nil
+	case isTimeType(tp):
+		return check(jen.Id("jsonAppendMarshaler").Call(jen.Id("buf"), value())), nil
+	case basicKind(tp) == "bytes":
+		return null(
+			appendBuf(jen.LitRune('"')),
+			appendBuf(jen.Qual("encoding/base64", "StdEncoding").Dot("EncodeToString").Call(value()).Op("...")),
+			appendBuf(jen.LitRune('"')),
+		), nil
+	case tp.ArrayType != nil:
+		i := loopName("i", depth)
+		code, err := o.encode(func() *jen.Statement { return value().Index(jen.Id(i)) }, *tp.ArrayType, false, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		return null(
+			appendBuf(jen.LitRune('[')),
+			jen.For(jen.Id(i).Op(":=").Range().Add(value())).Block(append([]jen.Code{
+				jen.If(jen.Id(i).Op(">").Lit(0)).Block(appendBuf(jen.LitRune(','))),
+			}, code...)...),
+			appendBuf(jen.LitRune(']')),
+		), nil
+	case tp.MapType != nil:
+		if !isStringType(tp.MapType.Key) {
+			return nil, fmt.Errorf("only maps with string keys are supported")
+		}
+		i, k, keys := loopName("i", depth), loopName("k", depth), loopName("keys", depth)
+		code, err := o.encode(func() *jen.Statement { return value().Index(jen.Id(k)) }, tp.MapType.Value, false, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		// the keys are sorted like encoding/json does.
+		return null(
+			jen.Id(keys).Op(":=").Make(jen.Index().String(), jen.Lit(0), jen.Len(value())),
+			jen.For(jen.Id(k).Op(":=").Range().Add(value())).Block(jen.Id(keys).Op("=").Append(jen.Id(keys), jen.Id(k))),
+			jen.Qual("sort", "Strings").Call(jen.Id(keys)),
+			appendBuf(jen.LitRune('{')),
+			jen.For(jen.List(jen.Id(i), jen.Id(k)).Op(":=").Range().Id(keys)).Block(append([]jen.Code{
+				jen.If(jen.Id(i).Op(">").Lit(0)).Block(appendBuf(jen.LitRune(','))),
+				jen.Id("buf").Op("=").Id("jsonAppendString").Call(jen.Id("buf"), jen.Id(k)),
+				appendBuf(jen.LitRune(':')),
+			}, code...)...),
+			appendBuf(jen.LitRune('}')),
+		), nil
+	}
+	switch basicKind(tp) {
+	case "string":
+		return []jen.Code{jen.Id("buf").Op("=").Id("jsonAppendString").Call(jen.Id("buf"), value())}, nil
+	case "bool":
+		return quote([]jen.Code{jen.Id("buf").Op("=").Qual("strconv", "AppendBool").Call(jen.Id("buf"), value())}), nil
+	case "int":
+		return quote([]jen.Code{jen.Id("buf").Op("=").Qual("strconv", "AppendInt").Call(
+			jen.Id("buf"), convert("int64", tp, value()), jen.Lit(10),
+		)}), nil
+	case "uint":
+		return quote([]jen.Code{jen.Id("buf").Op("=").Qual("strconv", "AppendUint").Call(
+			jen.Id("buf"), convert("uint64", tp, value()), jen.Lit(10),
+		)}), nil
+	case "float":
+		return quote(check(jen.Id("jsonAppendFloat").Call(jen.Id("buf"), convert("float64", tp, value()), jen.Lit(bitSize(tp))))), nil
+	}
+	return nil, fmt.Errorf("the type %s is not supported", tp.String())
+}
+
+// decode returns the code that reads the value from the lexer l.
+func (o *jsonOptions) decode(value func() *jen.Statement, tp Type, quoted bool, depth int) ([]jen.Code, error) {
+	notNull := func(code ...jen.Code) []jen.Code {
+		return []jen.Code{jen.If(jen.Op("!").Id("l").Dot("null").Call()).Block(code...)}
+	}
+	null := func(code ...jen.Code) []jen.Code {
+		return []jen.Code{jen.If(jen.Id("l").Dot("null").Call()).Block(
+			value().Op("=").Nil(),
+		).Else().Block(code...)}
+	}
+	switch {
+	case tp.Pointer:
+		elem := tp.Clone()
+		elem.Pointer = false
+		target := func() *jen.Statement { return jen.Op("*").Add(value()) }
+		if o.generated(elem) {
+			target = value
+		} else if elem.ArrayType != nil || elem.MapType != nil {
+			target = func() *jen.Statement { return jen.Parens(jen.Op("*").Add(value())) }
+		}
+		var code []jen.Code
+		var err error
+		if scalar := decodeScalar(target, elem, quoted); scalar != nil {
+			// null was already checked for the pointer.
+			code = []jen.Code{scalar}
+		} else if code, err = o.decode(target, elem, quoted, depth); err != nil {
+			return nil, err
+		}
+		return null(append([]jen.Code{
+			jen.If(value().Op("==").Nil()).Block(value().Op("=").New(elem.Code())),
+		}, code...)...), nil
+	case o.generated(tp):
+		return []jen.Code{value().Dot("readJSON").Call(jen.Id("l"))}, nil
+	case isTimeType(tp):
+		return []jen.Code{jen.Id("l").Dot("unmarshal").Call(jen.Op("&").Add(value()))}, nil
+	case basicKind(tp) == "bytes":
+		return null(value().Op("=").Id("l").Dot("bytes").Call()), nil
+	case tp.ArrayType != nil:
+		v := loopName("v", depth)
+		code, err := o.decode(func() *jen.Statement { return jen.Id(v) }, *tp.ArrayType, false, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		return null(
+			value().Op("=").Add(tp.Code()).Values(),
+			jen.Id("l").Dot("expect").Call(jen.LitRune('[')),
+			jen.For(jen.Op("!").Id("l").Dot("end").Call(jen.LitRune(']'))).Block(append(append([]jen.Code{
+				jen.Var().Id(v).Add(tp.ArrayType.Code()),
+			}, code...),
+				value().Op("=").Append(value(), jen.Id(v)),
+				jen.Id("l").Dot("comma").Call(jen.LitRune(']')),
+			)...),
+		), nil
+	case tp.MapType != nil:
+		if !isStringType(tp.MapType.Key) {
+			return nil, fmt.Errorf("only maps with string keys are supported")
+		}
+		k, v := loopName("k", depth), loopName("v", depth)
+		code, err := o.decode(func() *jen.Statement { return jen.Id(v) }, tp.MapType.Value, false, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		return null(
+			value().Op("=").Add(tp.Code()).Values(),
+			jen.Id("l").Dot("expect").Call(jen.LitRune('{')),
+			jen.For(jen.Op("!").Id("l").Dot("end").Call(jen.LitRune('}'))).Block(append(append([]jen.Code{
+				jen.Id(k).Op(":=").Id("l").Dot("str").Call(),
+				jen.Id("l").Dot("expect").Call(jen.LitRune(':')),
+				jen.Var().Id(v).Add(tp.MapType.Value.Code()),
+			}, code...),
+				value().Index(jen.Id(k)).Op("=").Id(v),
+				jen.Id("l").Dot("comma").Call(jen.LitRune('}')),
+			)...),
+		), nil
+	}
+	if code := decodeScalar(value, tp, quoted); code != nil {
+		return notNull(code), nil
+	}
+	return nil, fmt.Errorf("the type %s is not supported", tp.String())
+}
+
+// decodeScalar returns the code that reads the string, bool or number value from the lexer l,
+// it returns nil for the other types.
+func decodeScalar(value func() *jen.Statement, tp Type, quoted bool) jen.Code {
+	switch basicKind(tp) {
+	case "string":
+		return value().Op("=").Id("l").Dot("str").Call()
+	case "bool":
+		return value().Op("=").Id("l").Dot("boolean").Call(jen.Lit(quoted))
+	case "int":
+		return value().Op("=").Add(convert(tp.Qualifier, NewType("int64"),
+			jen.Id("l").Dot("integer").Call(jen.Lit(quoted), jen.Lit(bitSize(tp))),
+		))
+	case "uint":
+		return value().Op("=").Add(convert(tp.Qualifier, NewType("uint64"),
+			jen.Id("l").Dot("unsigned").Call(jen.Lit(quoted), jen.Lit(bitSize(tp))),
+		))
+	case "float":
+		return value().Op("=").Add(convert(tp.Qualifier, NewType("float64"),
+			jen.Id("l").Dot("float").Call(jen.Lit(quoted), jen.Lit(bitSize(tp))),
+		))
+	}
+	return nil
+}
+
+// generated returns true if the type has JSON methods generated by AddJSON.
+func (o *jsonOptions) generated(tp Type) bool {
+	return tp.Import == nil && !tp.Pointer && tp.RawType == nil && tp.ArrayType == nil && tp.MapType == nil &&
+		tp.Function == nil && tp.Struct == nil && contains(o.types, tp.Qualifier)
+}
+
+// NewJSONRuntime creates the JSON helpers used by the methods generated by AddJSON.
+//
+// It returns the unexported jsonLexer type that reads JSON values and the jsonAppendString, jsonAppendFloat
+// and jsonAppendMarshaler functions that write them, they must be generated once in every package that
+// uses AddJSON.
+func NewJSONRuntime() []Code {
+	lexer := NewStructWithFields("jsonLexer", []StructField{
+		*NewStructField("data", NewType("", ArrayTypeOption(NewType("byte")))),
+		*NewStructField("pos", NewType("int")),
+		*NewStructField("err", NewType("error")),
+	}, "jsonLexer reads JSON values from data, it keeps the first error and stops reading after it.")
+	l := func() *jen.Statement { return jen.Id("l") }
+	data := func() *jen.Statement { return l().Dot("data") }
+	pos := func() *jen.Statement { return l().Dot("pos") }
+	current := func() *jen.Statement { return data().Index(pos()) }
+	failed := func(results ...jen.Code) jen.Code {
+		return jen.If(l().Dot("err").Op("!=").Nil()).Block(jen.Return(results...))
+	}
+	fail := func(message string) jen.Code {
+		return l().Dot("fail").Call(jen.Lit(message))
+	}
+	byteParam := *NewParameter("c", NewType("byte"))
+	quotedParam := *NewParameter("quoted", NewType("bool"))
+	bitsParam := *NewParameter("bits", NewType("int"))
+	result := func(tp string) Parameter {
+		return *NewParameter("", NewType(tp))
+	}
+	method := func(name, doc string, params, results []Parameter, body ...jen.Code) {
+		lexer.AddMethod(NewFunction(
+			name,
+			ParamsFunctionOption(params...),
+			ResultsFunctionOption(results...),
+			BodyFunctionOption(body...),
+			DocsFunctionOption(Comment(name+" "+doc)),
+		), ReceiverNameMethodOption("l"))
+	}
+	number := func(name, parse, tp, doc string) {
+		args := []jen.Code{jen.Id("s"), jen.Lit(10), jen.Id("bits")}
+		if parse == "ParseFloat" {
+			args = []jen.Code{jen.Id("s"), jen.Id("bits")}
+		}
+		method(name, doc, []Parameter{quotedParam, bitsParam}, []Parameter{result(tp)},
+			jen.Id("s").Op(":=").Id("l").Dot("token").Call(jen.Id("quoted")),
+			failed(jen.Lit(0)),
+			jen.List(jen.Id("n"), jen.Err()).Op(":=").Qual("strconv", parse).Call(args...),
+			l().Dot("setErr").Call(jen.Err()),
+			jen.Return(jen.Id("n")),
+		)
+	}
+
+	method("fail", "sets the error with the message and the position if there is no error.",
+		[]Parameter{*NewParameter("message", NewType("string"))}, nil,
+		jen.If(l().Dot("err").Op("==").Nil()).Block(
+			l().Dot("err").Op("=").Qual("fmt", "Errorf").Call(jen.Lit("json: %s at offset %d"), jen.Id("message"), pos()),
+		),
+	)
+	method("setErr", "sets the error if there is no error.",
+		[]Parameter{*NewParameter("err", NewType("error"))}, nil,
+		jen.If(l().Dot("err").Op("==").Nil()).Block(l().Dot("err").Op("=").Err()),
+	)
+	method("ws", "skips the white space and returns the next byte, it returns 0 at the end of the data.",
+		nil, []Parameter{result("byte")},
+		jen.For(pos().Op("<").Len(data())).Block(
+			jen.Switch(current()).Block(
+				jen.Case(jen.LitRune(' '), jen.LitRune('\t'), jen.LitRune('\n'), jen.LitRune('\r')).Block(pos().Op("++")),
+				jen.Default().Block(jen.Return(current())),
+			),
+		),
+		jen.Return(jen.Lit(0)),
+	)
+	method("expect", "reads the byte c.",
+		[]Parameter{byteParam}, nil,
+		failed(),
+		jen.If(l().Dot("ws").Call().Op("!=").Id("c")).Block(
+			l().Dot("fail").Call(jen.Lit("expected ").Op("+").Id("string").Call(jen.Id("c"))),
+			jen.Return(),
+		),
+		pos().Op("++"),
+	)
+	method("end", "reads the byte c if it is next, it returns true if it was read or if there is an error.",
+		[]Parameter{byteParam}, []Parameter{result("bool")},
+		failed(jen.True()),
+		jen.If(l().Dot("ws").Call().Op("==").Id("c")).Block(pos().Op("++"), jen.Return(jen.True())),
+		jen.Return(jen.False()),
+	)
+	method("comma", "reads the comma between the values of an array or an object that ends with c.",
+		[]Parameter{byteParam}, nil,
+		failed(),
+		jen.Switch(l().Dot("ws").Call()).Block(
+			jen.Case(jen.LitRune(',')).Block(
+				pos().Op("++"),
+				jen.If(l().Dot("ws").Call().Op("==").Id("c")).Block(
+					l().Dot("fail").Call(jen.Lit("unexpected ").Op("+").Id("string").Call(jen.Id("c"))),
+				),
+			),
+			jen.Case(jen.Id("c")),
+			jen.Default().Block(l().Dot("fail").Call(jen.Lit("expected , or ").Op("+").Id("string").Call(jen.Id("c")))),
+		),
+	)
+	method("null", "reads null if it is next.",
+		nil, []Parameter{result("bool")},
+		jen.If(
+			l().Dot("err").Op("==").Nil().Op("&&").
+				Id("l").Dot("ws").Call().Op("==").LitRune('n').Op("&&").
+				Qual("bytes", "HasPrefix").Call(data().Index(pos(), jen.Empty()), jen.Index().Byte().Parens(jen.Lit("null"))),
+		).Block(
+			pos().Op("+=").Lit(4),
+			jen.Return(jen.True()),
+		),
+		jen.Return(jen.False()),
+	)
+	method("str", "reads a string.",
+		nil, []Parameter{result("string")},
+		failed(jen.Lit("")),
+		jen.If(l().Dot("ws").Call().Op("!=").LitRune('"')).Block(fail("expected string"), jen.Return(jen.Lit(""))),
+		pos().Op("++"),
+		jen.Var().Id("s").Index().Byte(),
+		jen.For(pos().Op("<").Len(data())).Block(
+			jen.Id("c").Op(":=").Add(current()),
+			pos().Op("++"),
+			jen.Switch().Block(
+				jen.Case(jen.Id("c").Op("==").LitRune('"')).Block(jen.Return(jen.String().Call(jen.Id("s")))),
+				jen.Case(jen.Id("c").Op("<").Lit(0x20)).Block(fail("invalid character in string"), jen.Return(jen.Lit(""))),
+				jen.Case(jen.Id("c").Op("!=").LitRune('\\')).Block(jen.Id("s").Op("=").Append(jen.Id("s"), jen.Id("c"))),
+				jen.Case(pos().Op(">=").Len(data())).Block(fail("unexpected end of string"), jen.Return(jen.Lit(""))),
+				jen.Default().Block(
+					jen.Id("e").Op(":=").Add(current()),
+					pos().Op("++"),
+					jen.Switch(jen.Id("e")).Block(
+						jen.Case(jen.LitRune('"'), jen.LitRune('\\'), jen.LitRune('/')).Block(jen.Id("s").Op("=").Append(jen.Id("s"), jen.Id("e"))),
+						jen.Case(jen.LitRune('b')).Block(jen.Id("s").Op("=").Append(jen.Id("s"), jen.LitRune('\b'))),
+						jen.Case(jen.LitRune('f')).Block(jen.Id("s").Op("=").Append(jen.Id("s"), jen.LitRune('\f'))),
+						jen.Case(jen.LitRune('n')).Block(jen.Id("s").Op("=").Append(jen.Id("s"), jen.LitRune('\n'))),
+						jen.Case(jen.LitRune('r')).Block(jen.Id("s").Op("=").Append(jen.Id("s"), jen.LitRune('\r'))),
+						jen.Case(jen.LitRune('t')).Block(jen.Id("s").Op("=").Append(jen.Id("s"), jen.LitRune('\t'))),
+						jen.Case(jen.LitRune('u')).Block(
+							jen.Id("r").Op(":=").Id("l").Dot("hex").Call(),
+							jen.If(
+								jen.Qual("unicode/utf16", "IsSurrogate").Call(jen.Id("r")).Op("&&").
+									Qual("bytes", "HasPrefix").Call(data().Index(pos(), jen.Empty()), jen.Index().Byte().Parens(jen.Lit(`\u`))),
+							).Block(
+								pos().Op("+=").Lit(2),
+								jen.Id("r").Op("=").Qual("unicode/utf16", "DecodeRune").Call(jen.Id("r"), jen.Id("l").Dot("hex").Call()),
+							),
+							jen.Id("s").Op("=").Qual("unicode/utf8", "AppendRune").Call(jen.Id("s"), jen.Id("r")),
+						),
+						jen.Default().Block(fail("invalid escape in string"), jen.Return(jen.Lit(""))),
+					),
+				),
+			),
+		),
+		fail("unexpected end of string"),
+		jen.Return(jen.Lit("")),
+	)
+	method("hex", "reads the 4 hexadecimal digits of a unicode escape.",
+		nil, []Parameter{result("rune")},
+		jen.If(pos().Op("+").Lit(4).Op(">").Len(data())).Block(
+			fail("invalid unicode escape"),
+			jen.Return(jen.Qual("unicode/utf8", "RuneError")),
+		),
+		jen.List(jen.Id("n"), jen.Err()).Op(":=").Qual("strconv", "ParseUint").Call(
+			jen.String().Call(data().Index(pos(), pos().Op("+").Lit(4))), jen.Lit(16), jen.Lit(32),
+		),
+		jen.If(jen.Err().Op("!=").Nil()).Block(
+			fail("invalid unicode escape"),
+			jen.Return(jen.Qual("unicode/utf8", "RuneError")),
+		),
+		pos().Op("+=").Lit(4),
+		jen.Return(jen.Rune().Call(jen.Id("n"))),
+	)
+	method("token", "reads a number token or a string if quoted is true.",
+		[]Parameter{quotedParam}, []Parameter{result("string")},
+		jen.If(jen.Id("quoted")).Block(jen.Return(l().Dot("str").Call())),
+		failed(jen.Lit("")),
+		l().Dot("ws").Call(),
+		jen.Id("start").Op(":=").Add(pos()),
+		jen.For(
+			pos().Op("<").Len(data()).Op("&&").
+				Qual("strings", "IndexByte").Call(jen.Lit("+-.0123456789eE"), current()).Op("!=").Lit(-1),
+		).Block(pos().Op("++")),
+		jen.If(jen.Id("start").Op("==").Add(pos())).Block(fail("expected number")),
+		jen.Return(jen.String().Call(data().Index(jen.Id("start"), pos()))),
+	)
+	number("integer", "ParseInt", "int64", "reads an integer with the bit size.")
+	number("unsigned", "ParseUint", "uint64", "reads an unsigned integer with the bit size.")
+	number("float", "ParseFloat", "float64", "reads a float with the bit size.")
+	method("boolean", "reads a bool or a string with a bool if quoted is true.",
+		[]Parameter{quotedParam}, []Parameter{result("bool")},
+		jen.Var().Id("s").String(),
+		jen.If(jen.Id("quoted")).Block(
+			jen.Id("s").Op("=").Id("l").Dot("str").Call(),
+		).Else().Block(
+			l().Dot("ws").Call(),
+			jen.Id("start").Op(":=").Add(pos()),
+			jen.For(pos().Op("<").Len(data()).Op("&&").Add(current()).Op(">=").LitRune('a').Op("&&").Add(current()).Op("<=").LitRune('z')).Block(
+				pos().Op("++"),
+			),
+			jen.Id("s").Op("=").String().Call(data().Index(jen.Id("start"), pos())),
+		),
+		failed(jen.False()),
+		jen.Switch(jen.Id("s")).Block(
+			jen.Case(jen.Lit("true")).Block(jen.Return(jen.True())),
+			jen.Case(jen.Lit("false")).Block(jen.Return(jen.False())),
+		),
+		fail("expected bool"),
+		jen.Return(jen.False()),
+	)
+	method("bytes", "reads a base64 string.",
+		nil, []Parameter{*NewParameter("", NewType("", ArrayTypeOption(NewType("byte"))))},
+		jen.List(jen.Id("b"), jen.Err()).Op(":=").Qual("encoding/base64", "StdEncoding").Dot("DecodeString").Call(l().Dot("str").Call()),
+		l().Dot("setErr").Call(jen.Err()),
+		jen.Return(jen.Id("b")),
+	)
+	method("raw", "reads any value and returns its JSON.",
+		nil, []Parameter{*NewParameter("", NewType("", ArrayTypeOption(NewType("byte"))))},
+		failed(jen.Nil()),
+		jen.Id("c").Op(":=").Id("l").Dot("ws").Call(),
+		jen.Id("start").Op(":=").Add(pos()),
+		jen.Switch(jen.Id("c")).Block(
+			jen.Case(jen.LitRune('"')).Block(l().Dot("str").Call()),
+			jen.Case(jen.LitRune('{')).Block(
+				pos().Op("++"),
+				jen.For(jen.Op("!").Id("l").Dot("end").Call(jen.LitRune('}'))).Block(
+					l().Dot("str").Call(),
+					l().Dot("expect").Call(jen.LitRune(':')),
+					l().Dot("raw").Call(),
+					l().Dot("comma").Call(jen.LitRune('}')),
+				),
+			),
+			jen.Case(jen.LitRune('[')).Block(
+				pos().Op("++"),
+				jen.For(jen.Op("!").Id("l").Dot("end").Call(jen.LitRune(']'))).Block(
+					l().Dot("raw").Call(),
+					l().Dot("comma").Call(jen.LitRune(']')),
+				),
+			),
+			jen.Case(jen.LitRune('t'), jen.LitRune('f')).Block(l().Dot("boolean").Call(jen.False())),
+			jen.Case(jen.LitRune('n')).Block(
+				jen.If(jen.Op("!").Id("l").Dot("null").Call()).Block(fail("invalid value")),
+			),
+			jen.Default().Block(l().Dot("token").Call(jen.False())),
+		),
+		jen.Return(data().Index(jen.Id("start"), pos())),
+	)
+	method("unmarshal", "reads any value and unmarshals it with v.",
+		[]Parameter{*NewParameter("v", NewRawType(jen.Interface(jen.Id("UnmarshalJSON").Params(jen.Index().Byte()).Error())))}, nil,
+		jen.Id("raw").Op(":=").Id("l").Dot("raw").Call(),
+		jen.If(l().Dot("err").Op("==").Nil()).Block(l().Dot("setErr").Call(jen.Id("v").Dot("UnmarshalJSON").Call(jen.Id("raw")))),
+	)
+	method("eof", "checks that there is only white space left.",
+		nil, nil,
+		l().Dot("ws").Call(),
+		jen.If(l().Dot("err").Op("==").Nil().Op("&&").Add(pos()).Op("<").Len(data())).Block(
+			fail("unexpected data after value"),
+		),
+	)
+
+	buf := *NewParameter("buf", NewType("", ArrayTypeOption(NewType("byte"))))
+	bufResult := *NewParameter("", NewType("", ArrayTypeOption(NewType("byte"))))
+	hex := func(v jen.Code) *jen.Statement { return jen.Lit("0123456789abcdef").Index(v) }
+	appendString := NewFunction(
+		"jsonAppendString",
+		ParamsFunctionOption(buf, *NewParameter("s", NewType("string"))),
+		ResultsFunctionOption(bufResult),
+		BodyFunctionOption(
+			jen.Id("buf").Op("=").Append(jen.Id("buf"), jen.LitRune('"')),
+			jen.For(jen.Id("i").Op(":=").Lit(0), jen.Id("i").Op("<").Len(jen.Id("s")), jen.Empty()).Block(
+				jen.Id("c").Op(":=").Id("s").Index(jen.Id("i")),
+				jen.If(jen.Id("c").Op("<").Qual("unicode/utf8", "RuneSelf")).Block(
+					jen.Switch().Block(
+						jen.Case(jen.Id("c").Op("==").LitRune('"').Op("||").Id("c").Op("==").LitRune('\\')).Block(
+							jen.Id("buf").Op("=").Append(jen.Id("buf"), jen.LitRune('\\'), jen.Id("c")),
+						),
+						jen.Case(jen.Id("c").Op("==").LitRune('\b')).Block(jen.Id("buf").Op("=").Append(jen.Id("buf"), jen.LitRune('\\'), jen.LitRune('b'))),
+						jen.Case(jen.Id("c").Op("==").LitRune('\f')).Block(jen.Id("buf").Op("=").Append(jen.Id("buf"), jen.LitRune('\\'), jen.LitRune('f'))),
+						jen.Case(jen.Id("c").Op("==").LitRune('\n')).Block(jen.Id("buf").Op("=").Append(jen.Id("buf"), jen.LitRune('\\'), jen.LitRune('n'))),
+						jen.Case(jen.Id("c").Op("==").LitRune('\r')).Block(jen.Id("buf").Op("=").Append(jen.Id("buf"), jen.LitRune('\\'), jen.LitRune('r'))),
+						jen.Case(jen.Id("c").Op("==").LitRune('\t')).Block(jen.Id("buf").Op("=").Append(jen.Id("buf"), jen.LitRune('\\'), jen.LitRune('t'))),
+						// the HTML characters are escaped like encoding/json does.
+						jen.Case(
+							jen.Id("c").Op("<").Lit(0x20).Op("||").Id("c").Op("==").LitRune('<').
+								Op("||").Id("c").Op("==").LitRune('>').Op("||").Id("c").Op("==").LitRune('&'),
+						).Block(jen.Id("buf").Op("=").Append(
+							jen.Id("buf"), jen.LitRune('\\'), jen.LitRune('u'), jen.LitRune('0'), jen.LitRune('0'),
+							hex(jen.Id("c").Op(">>").Lit(4)), hex(jen.Id("c").Op("&").Lit(0xf)),
+						)),
+						jen.Default().Block(jen.Id("buf").Op("=").Append(jen.Id("buf"), jen.Id("c"))),
+					),
+					jen.Id("i").Op("++"),
+					jen.Continue(),
+				),
+				jen.List(jen.Id("r"), jen.Id("size")).Op(":=").Qual("unicode/utf8", "DecodeRuneInString").Call(jen.Id("s").Index(jen.Id("i"), jen.Empty())),
+				jen.Switch().Block(
+					jen.Case(jen.Id("r").Op("==").Qual("unicode/utf8", "RuneError").Op("&&").Id("size").Op("==").Lit(1)).Block(
+						jen.Id("buf").Op("=").Qual("unicode/utf8", "AppendRune").Call(jen.Id("buf"), jen.Qual("unicode/utf8", "RuneError")),
+					),
+					jen.Case(jen.Id("r").Op("==").LitRune('\u2028').Op("||").Id("r").Op("==").LitRune('\u2029')).Block(
+						jen.Id("buf").Op("=").Append(
+							jen.Id("buf"), jen.LitRune('\\'), jen.LitRune('u'), jen.LitRune('2'), jen.LitRune('0'), jen.LitRune('2'),
+							hex(jen.Id("r").Op("&").Lit(0xf)),
+						),
+					),
+					jen.Default().Block(jen.Id("buf").Op("=").Append(jen.Id("buf"), jen.Id("s").Index(jen.Id("i"), jen.Id("i").Op("+").Id("size")).Op("..."))),
+				),
+				jen.Id("i").Op("+=").Id("size"),
+			),
+			jen.Return(jen.Append(jen.Id("buf"), jen.LitRune('"'))),
+		),
+		DocsFunctionOption(
+			"jsonAppendString appends the JSON string of s to buf, it is escaped like encoding/json escapes strings",
+			"including the HTML characters, invalid UTF-8 is replaced with the replacement character.",
+		),
+	)
+	appendFloat := NewFunction(
+		"jsonAppendFloat",
+		ParamsFunctionOption(buf, *NewParameter("f", NewType("float64")), *NewParameter("bits", NewType("int"))),
+		ResultsFunctionOption(bufResult, *NewParameter("", NewType("error"))),
+		BodyFunctionOption(
+			jen.If(jen.Qual("math", "IsNaN").Call(jen.Id("f")).Op("||").Qual("math", "IsInf").Call(jen.Id("f"), jen.Lit(0))).Block(
+				jen.Return(jen.Nil(), jen.Qual("fmt", "Errorf").Call(jen.Lit("json: unsupported value %v"), jen.Id("f"))),
+			),
+			// the number format is the same as the format of encoding/json (e.x 1e-7 and 1e+21).
+			jen.Id("abs").Op(":=").Qual("math", "Abs").Call(jen.Id("f")),
+			jen.Id("format").Op(":=").Byte().Call(jen.LitRune('f')),
+			jen.If(jen.Id("abs").Op("!=").Lit(0)).Block(
+				jen.If(
+					jen.Id("bits").Op("==").Lit(64).Op("&&").Parens(jen.Id("abs").Op("<").Lit(1e-6).Op("||").Id("abs").Op(">=").Lit(1e21)).
+						Op("||").Id("bits").Op("==").Lit(32).Op("&&").Parens(
+						jen.Float32().Call(jen.Id("abs")).Op("<").Lit(1e-6).Op("||").Float32().Call(jen.Id("abs")).Op(">=").Lit(1e21),
+					),
+				).Block(jen.Id("format").Op("=").LitRune('e')),
+			),
+			jen.Id("buf").Op("=").Qual("strconv", "AppendFloat").Call(jen.Id("buf"), jen.Id("f"), jen.Id("format"), jen.Lit(-1), jen.Id("bits")),
+			jen.If(jen.Id("format").Op("==").LitRune('e')).Block(
+				// e-07 is written as e-7.
+				jen.Id("n").Op(":=").Len(jen.Id("buf")),
+				jen.If(
+					jen.Id("n").Op(">=").Lit(4).Op("&&").Id("buf").Index(jen.Id("n").Op("-").Lit(4)).Op("==").LitRune('e').
+						Op("&&").Id("buf").Index(jen.Id("n").Op("-").Lit(3)).Op("==").LitRune('-').
+						Op("&&").Id("buf").Index(jen.Id("n").Op("-").Lit(2)).Op("==").LitRune('0'),
+				).Block(
+					jen.Id("buf").Index(jen.Id("n").Op("-").Lit(2)).Op("=").Id("buf").Index(jen.Id("n").Op("-").Lit(1)),
+					jen.Id("buf").Op("=").Id("buf").Index(jen.Empty(), jen.Id("n").Op("-").Lit(1)),
+				),
+			),
+			jen.Return(jen.Id("buf"), jen.Nil()),
+		),
+		DocsFunctionOption("jsonAppendFloat appends the JSON number of f to buf, NaN and infinities are not supported."),
+	)
+	appendMarshaler := NewFunction(
+		"jsonAppendMarshaler",
+		ParamsFunctionOption(buf, *NewParameter("m", NewRawType(jen.Interface(jen.Id("MarshalJSON").Params().Params(jen.Index().Byte(), jen.Error()))))),
+		ResultsFunctionOption(bufResult, *NewParameter("", NewType("error"))),
+		BodyFunctionOption(
+			jen.List(jen.Id("b"), jen.Err()).Op(":=").Id("m").Dot("MarshalJSON").Call(),
+			jen.If(jen.Err().Op("!=").Nil()).Block(jen.Return(jen.Nil(), jen.Err())),
+			jen.Return(jen.Append(jen.Id("buf"), jen.Id("b").Op("...")), jen.Nil()),
+		),
+		DocsFunctionOption("jsonAppendMarshaler appends the JSON returned by m to buf."),
+	)
+	return []Code{lexer, appendString, appendFloat, appendMarshaler}
+}
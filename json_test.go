@@ -0,0 +1,343 @@
+package code
+
+import (
+	"testing"
+)
+
+func TestAddJSON(t *testing.T) {
+	json := func(v string) *FieldTags { return NewFieldTags("json", v) }
+	tests := []struct {
+		name       string
+		structName string
+		fields     []StructField
+		want       string
+		wantErr    bool
+	}{
+		{
+			name:       "Should encode and decode the fields",
+			structName: "User",
+			fields: []StructField{
+				*NewStructFieldWithTag("Name", NewType("string"), json("name,omitempty")),
+				*NewStructFieldWithTag("ID", NewType("int64"), json("id,string")),
+				*NewStructFieldWithTag("Age", NewType("int", PointerTypeOption()), json("age")),
+				*NewStructFieldWithTag("Skip", NewType("string"), json("-")),
+				*NewStructField("secret", NewType("string")),
+			},
+			want: `package test
+
+import "strconv"
+
+type User struct {
+	Name   string ` + "`json:\"name,omitempty\"`" + `
+	ID     int64  ` + "`json:\"id,string\"`" + `
+	Age    *int   ` + "`json:\"age\"`" + `
+	Skip   string ` + "`json:\"-\"`" + `
+	secret string
+}
+
+// MarshalJSON returns the JSON encoding of the User.
+func (u User) MarshalJSON() ([]byte, error) {
+	return u.appendJSON(nil)
+}
+
+// appendJSON appends the JSON encoding of the User to buf.
+func (u User) appendJSON(buf []byte) ([]byte, error) {
+	start := len(buf)
+	if u.Name != "" {
+		buf = append(buf, ",\"name\":"...)
+		buf = jsonAppendString(buf, u.Name)
+	}
+	buf = append(buf, ",\"id\":"...)
+	buf = append(buf, '"')
+	buf = strconv.AppendInt(buf, u.ID, 10)
+	buf = append(buf, '"')
+	buf = append(buf, ",\"age\":"...)
+	if u.Age == nil {
+		buf = append(buf, "null"...)
+	} else {
+		buf = strconv.AppendInt(buf, int64(*u.Age), 10)
+	}
+	if len(buf) == start {
+		buf = append(buf, '{')
+	} else {
+		buf[start] = '{'
+	}
+	return append(buf, '}'), nil
+}
+
+// UnmarshalJSON sets the User from its JSON encoding.
+func (u *User) UnmarshalJSON(data []byte) error {
+	l := &jsonLexer{data: data}
+	u.readJSON(l)
+	l.eof()
+	return l.err
+}
+
+// readJSON reads the User from the lexer.
+func (u *User) readJSON(l *jsonLexer) {
+	if l.null() {
+		return
+	}
+	l.expect('{')
+	for !l.end('}') {
+		key := l.str()
+		l.expect(':')
+		switch key {
+		case "name":
+			if !l.null() {
+				u.Name = l.str()
+			}
+		case "id":
+			if !l.null() {
+				u.ID = l.integer(true, 64)
+			}
+		case "age":
+			if l.null() {
+				u.Age = nil
+			} else {
+				if u.Age == nil {
+					u.Age = new(int)
+				}
+				*u.Age = int(l.integer(false, 0))
+			}
+		default:
+			l.raw()
+		}
+		l.comma('}')
+	}
+}
+`,
+		},
+		{
+			name:       "Should not use the loop variables as the receiver",
+			structName: "Item",
+			fields:     []StructField{*NewStructFieldWithTag("Tags", NewType("", ArrayTypeOption(NewType("string"))), json("tags"))},
+			want: `package test
+
+type Item struct {
+	Tags []string ` + "`json:\"tags\"`" + `
+}
+
+// MarshalJSON returns the JSON encoding of the Item.
+func (item Item) MarshalJSON() ([]byte, error) {
+	return item.appendJSON(nil)
+}
+
+// appendJSON appends the JSON encoding of the Item to buf.
+func (item Item) appendJSON(buf []byte) ([]byte, error) {
+	start := len(buf)
+	buf = append(buf, ",\"tags\":"...)
+	if item.Tags == nil {
+		buf = append(buf, "null"...)
+	} else {
+		buf = append(buf, '[')
+		for i := range item.Tags {
+			if i > 0 {
+				buf = append(buf, ',')
+			}
+			buf = jsonAppendString(buf, item.Tags[i])
+		}
+		buf = append(buf, ']')
+	}
+	if len(buf) == start {
+		buf = append(buf, '{')
+	} else {
+		buf[start] = '{'
+	}
+	return append(buf, '}'), nil
+}
+
+// UnmarshalJSON sets the Item from its JSON encoding.
+func (item *Item) UnmarshalJSON(data []byte) error {
+	l := &jsonLexer{data: data}
+	item.readJSON(l)
+	l.eof()
+	return l.err
+}
+
+// readJSON reads the Item from the lexer.
+func (item *Item) readJSON(l *jsonLexer) {
+	if l.null() {
+		return
+	}
+	l.expect('{')
+	for !l.end('}') {
+		key := l.str()
+		l.expect(':')
+		switch key {
+		case "tags":
+			if l.null() {
+				item.Tags = nil
+			} else {
+				item.Tags = []string{}
+				l.expect('[')
+				for !l.end(']') {
+					var v string
+					if !l.null() {
+						v = l.str()
+					}
+					item.Tags = append(item.Tags, v)
+					l.comma(']')
+				}
+			}
+		default:
+			l.raw()
+		}
+		l.comma('}')
+	}
+}
+`,
+		},
+		{
+			name:       "Should return an error for embedded fields",
+			structName: "User",
+			fields:     []StructField{*NewStructField("", NewType("Base"))},
+			wantErr:    true,
+		},
+		{
+			name:       "Should return an error for maps without string keys",
+			structName: "User",
+			fields:     []StructField{*NewStructField("M", NewType("", MapTypeOption(NewType("int"), NewType("string"))))},
+			wantErr:    true,
+		},
+		{
+			name:       "Should return an error for unsupported types",
+			structName: "User",
+			fields:     []StructField{*NewStructField("V", NewType("any"))},
+			wantErr:    true,
+		},
+		{
+			name:       "Should return an error for the string option of strings",
+			structName: "User",
+			fields:     []StructField{*NewStructFieldWithTag("S", NewType("string"), json("s,string"))},
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := NewStructWithFields(tt.structName, tt.fields)
+			_, err := AddJSON(st)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("AddJSON() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got := NewFile("test", st).String(); got != tt.want {
+				t.Errorf("AddJSON() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestAddJSON_RoundTrip compiles the generated code and checks it against encoding/json.
+func TestAddJSON_RoundTrip(t *testing.T) {
+	json := func(v string) *FieldTags { return NewFieldTags("json", v) }
+	user := NewStructWithFields("User", []StructField{
+		*NewStructFieldWithTag("Name", NewType("string"), json("name")),
+		*NewStructFieldWithTag("Email", NewType("string"), json("email,omitempty")),
+		*NewStructFieldWithTag("Age", NewType("int", PointerTypeOption()), json("age")),
+		*NewStructFieldWithTag("ID", NewType("int64"), json("id,string")),
+		*NewStructFieldWithTag("Score", NewType("float32"), json("score")),
+		*NewStructFieldWithTag("Active", NewType("bool"), json("active,string")),
+		*NewStructFieldWithTag("Small", NewType("uint8"), json("small")),
+		*NewStructFieldWithTag("Tags", NewType("", ArrayTypeOption(NewType("string"))), json("tags")),
+		*NewStructFieldWithTag("Data", NewType("", ArrayTypeOption(NewType("byte"))), json("data")),
+		*NewStructFieldWithTag("Created", NewType("Time", ImportTypeOption(*NewImport("", "time"))), json("created")),
+		*NewStructFieldWithTag("Updated", NewType("Time", ImportTypeOption(*NewImport("", "time"))), json("updated,omitempty")),
+		*NewStructFieldWithTag("Ratio", NewType("float64"), json("ratio")),
+		*NewStructFieldWithTag("Address", NewType("Address", PointerTypeOption()), json("address")),
+		*NewStructField("Others", NewType("", ArrayTypeOption(NewType("Address")))),
+		*NewStructFieldWithTag("ByName", NewType("", MapTypeOption(NewType("string"), NewType("", ArrayTypeOption(NewType("Address", PointerTypeOption()))))), json("by_name")),
+		*NewStructFieldWithTag("Skip", NewType("string"), json("-")),
+		*NewStructField("Parent", NewType("User", PointerTypeOption())),
+		*NewStructFieldWithTag("Matrix", NewType("", ArrayTypeOption(NewType("", ArrayTypeOption(NewType("float64"))))), json("matrix,omitempty")),
+	})
+	address := NewStructWithFields("Address", []StructField{*NewStructFieldWithTag("Street", NewType("string"), json("street"))})
+	if _, err := AddJSON(user, TypesJSONOption("Address")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := AddJSON(address); err != nil {
+		t.Fatal(err)
+	}
+	runGeneratedTests(t, "roundtrip", map[string]string{
+		"gen.go":        NewFile("roundtrip", append([]Code{user, address}, NewJSONRuntime()...)...).String(),
+		"gen_test.go":   jsonRoundTripTest,
+		"plain_test.go": "package roundtrip\n\n// plainUser has the fields of User without the generated methods.\ntype plainUser User\n",
+	})
+}
+
+const jsonRoundTripTest = `package roundtrip
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestRoundTrip(t *testing.T) {
+	age := 30
+	u := User{
+		Name: "a \"quoted\" \\ name\n\t\b\f é 😀 <>&\x01\u2028\u2029", Age: &age, ID: 1 << 60, Score: 1e-7, Active: true, Small: 255,
+		Ratio: -1e-7,
+		Tags: []string{}, Data: []byte{0, 1, 2, 255}, Created: time.Date(2020, 1, 2, 3, 4, 5, 6, time.UTC),
+		Address: &Address{Street: "s"}, Others: []Address{{Street: "o"}},
+		ByName: map[string][]*Address{"b": {nil, {Street: "x"}}, "a": nil},
+		Parent: &User{Name: "p", Score: 1e21, Ratio: 123456789}, Matrix: [][]float64{{1e21, -0.5, 1e20, 0.000001, 1.5e-300}, nil},
+	}
+	got, err := json.Marshal(u)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := json.Marshal(plainUser(u))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Fatalf("MarshalJSON() = %s, want %s", got, want)
+	}
+	for _, data := range [][]byte{got, want} {
+		var decoded User
+		if err := json.Unmarshal(data, &decoded); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(u, decoded) {
+			t.Fatalf("UnmarshalJSON(%s) = %#v, want %#v", data, decoded, u)
+		}
+	}
+}
+
+func TestUnmarshalErrors(t *testing.T) {
+	for _, data := range []string{` + "`" + `{"name":1}` + "`" + `, ` + "`" + `{"name":"a",}` + "`" + `, ` + "`" + `{"small":256}` + "`" + `, ` + "`" + `{"name":"a"} x` + "`" + `, ` + "`" + `[` + "`" + `, ""} {
+		var u User
+		if err := u.UnmarshalJSON([]byte(data)); err == nil {
+			t.Errorf("UnmarshalJSON(%s) expected an error", data)
+		}
+	}
+}
+
+func TestMarshalInvalidUTF8(t *testing.T) {
+	u := User{Name: "a\xffb\xe2\x80", Tags: []string{"\xc3"}, ByName: map[string][]*Address{"\xff<": nil}}
+	got, err := json.Marshal(u)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := json.Marshal(plainUser(u))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Fatalf("MarshalJSON() = %s, want %s", got, want)
+	}
+}
+
+func TestUnmarshalUnknown(t *testing.T) {
+	var u User
+	data := ` + "`" + ` {"unknown": {"a": [1, "b", null, true, {"c": -1.5e3}]}, "name": "é😀\/"} ` + "`" + `
+	if err := u.UnmarshalJSON([]byte(data)); err != nil || u.Name != "é\U0001F600/" {
+		t.Fatalf("UnmarshalJSON() = %q, %v", u.Name, err)
+	}
+}
+`
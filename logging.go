@@ -0,0 +1,157 @@
+package code
+
+import (
+	"strings"
+
+	"github.com/dave/jennifer/jen"
+)
+
+// LoggingOptions is used when you call NewLoggingMiddleware, it is a handy way to allow multiple configurations
+// for the generated logging middleware.
+type LoggingOptions func(o *loggingOptions)
+
+type loggingOptions struct {
+	params []string
+	redact []string
+}
+
+// ParamsLoggingOption only logs the parameters with the given names,
+// by default all parameters besides context.Context are logged.
+func ParamsLoggingOption(names ...string) LoggingOptions {
+	return func(o *loggingOptions) {
+		o.params = append(o.params, names...)
+	}
+}
+
+// RedactLoggingOption logs the parameters with the given names as [REDACTED] in every method.
+func RedactLoggingOption(names ...string) LoggingOptions {
+	return func(o *loggingOptions) {
+		o.redact = append(o.redact, names...)
+	}
+}
+
+// NewLoggingMiddleware creates a log/slog logging middleware for the interface (see NewMiddleware).
+//
+// Every method logs its name, the logged parameters, the duration of the call and the returned error if the
+// method returns one, calls that return an error are logged with the error level.
+// Unnamed parameters are named arg{index} and are logged with that name, the took and err keys get a number suffix
+// if a logged parameter has the same name (e.x err1).
+//
+// Methods can change what is logged with a @log annotation in their docs:
+//
+//	// @log(skip="data", redact="password,token")
+//	Login(ctx context.Context, user, password, token string, data []byte) error
+//
+// skip removes parameters from the log and redact logs them as [REDACTED].
+func NewLoggingMiddleware(name string, iface *Interface, options ...LoggingOptions) []Code {
+	opts := &loggingOptions{}
+	for _, o := range options {
+		o(opts)
+	}
+	annotations := map[string]map[string][]string{}
+	for _, m := range iface.Methods {
+		annotations[m.Name] = logAnnotation(m.docs)
+	}
+	hook := func(m MiddlewareMethod) ([]jen.Code, []jen.Code) {
+		annotation := annotations[m.Method.Name]
+		// the variables of the deferred function must not shadow the parameters and results it logs.
+		used := []string{m.Recv}
+		for _, p := range append(append([]Parameter{}, m.Method.Params...), m.Method.Results...) {
+			used = append(used, p.Name)
+		}
+		begin, level := unusedName("begin", used), unusedName("level", used)
+		ctx := jen.Qual("context", "Background").Call()
+		args := []jen.Code{jen.Lit(m.Method.Name)}
+		// keys are the logged attribute keys, the took and err keys must not repeat a parameter key.
+		var keys []string
+		for _, p := range m.Method.Params {
+			if isContextType(p.Type) {
+				ctx = jen.Id(p.Name)
+				continue
+			}
+			if contains(annotation["skip"], p.Name) || (opts.params != nil && !contains(opts.params, p.Name)) {
+				continue
+			}
+			keys = append(keys, p.Name)
+			if contains(annotation["redact"], p.Name) || contains(opts.redact, p.Name) {
+				args = append(args, jen.Lit(p.Name), jen.Lit("[REDACTED]"))
+				continue
+			}
+			args = append(args, jen.Lit(p.Name), jen.Id(p.Name))
+		}
+		took := unusedName("took", keys)
+		keys = append(keys, took)
+		args = append(args, jen.Lit(took), jen.Qual("time", "Since").Call(jen.Id(begin)))
+
+		logLevel := jen.Qual("log/slog", "LevelInfo")
+		var body []jen.Code
+		results := m.Method.Results
+		if len(results) > 0 && isErrorType(results[len(results)-1].Type) {
+			err := results[len(results)-1].Name
+			args = append(args, jen.Lit(unusedName("err", keys)), jen.Id(err))
+			logLevel = jen.Id(level)
+			body = append(
+				body,
+				jen.Id(level).Op(":=").Qual("log/slog", "LevelInfo"),
+				jen.If(jen.Id(err).Op("!=").Nil()).Block(
+					jen.Id(level).Op("=").Qual("log/slog", "LevelError"),
+				),
+			)
+		}
+		body = append(body, jen.Id(m.Recv).Dot("logger").Dot("Log").Call(
+			append([]jen.Code{ctx, logLevel}, args...)...,
+		))
+		before := jen.Defer().Func().Params(jen.Id(begin).Qual("time", "Time")).Block(body...).Call(
+			jen.Qual("time", "Now").Call(),
+		)
+		return []jen.Code{before}, nil
+	}
+	return NewMiddleware(
+		name,
+		iface,
+		hook,
+		FieldsMiddlewareOption(*NewStructField(
+			"logger",
+			NewType("Logger", ImportTypeOption(Import{Path: "log/slog"}), PointerTypeOption()),
+		)),
+		DocsMiddlewareOption(Comment(
+			"New"+exportedName(name)+" returns a "+iface.Name+"Middleware that logs every call to "+iface.Name+".",
+		)),
+	)
+}
+
+// logAnnotation parses the @log(key="value,value") annotation of the docs.
+func logAnnotation(docs []Comment) map[string][]string {
+	values := map[string][]string{}
+	for _, d := range docs {
+		s := strings.TrimSpace(string(d))
+		if !strings.HasPrefix(s, "@log(") || !strings.HasSuffix(s, ")") {
+			continue
+		}
+		s = strings.TrimSuffix(strings.TrimPrefix(s, "@log("), ")")
+		for s != "" {
+			eq := strings.Index(s, "=\"")
+			if eq == -1 {
+				break
+			}
+			key := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(s[:eq]), ","))
+			rest := s[eq+2:]
+			end := strings.Index(rest, "\"")
+			if end == -1 {
+				break
+			}
+			for _, v := range strings.Split(rest[:end], ",") {
+				if v = strings.TrimSpace(v); v != "" {
+					values[key] = append(values[key], v)
+				}
+			}
+			s = rest[end+1:]
+		}
+	}
+	return values
+}
+
+func isContextType(tp Type) bool {
+	return tp.Import != nil && tp.Import.Path == "context" && tp.Qualifier == "Context" &&
+		!tp.Pointer && tp.RawType == nil && tp.ArrayType == nil && tp.MapType == nil
+}
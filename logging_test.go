@@ -0,0 +1,260 @@
+package code
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_logAnnotation(t *testing.T) {
+	tests := []struct {
+		name string
+		docs []Comment
+		want map[string][]string
+	}{
+		{
+			name: "Should return no values if there is no annotation",
+			docs: []Comment{"Login logs in the user."},
+			want: map[string][]string{},
+		},
+		{
+			name: "Should parse the annotation values",
+			docs: []Comment{"Login logs in the user.", ` @log(skip="data", redact="password, token")`},
+			want: map[string][]string{
+				"skip":   {"data"},
+				"redact": {"password", "token"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := logAnnotation(tt.docs); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("logAnnotation() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewLoggingMiddleware(t *testing.T) {
+	iface := NewInterface("Service", []InterfaceMethod{
+		NewInterfaceMethod("Login",
+			ParamsFunctionOption(
+				*NewParameter("ctx", NewType("Context", ImportTypeOption(*NewImport("", "context")))),
+				*NewParameter("user", NewType("string")),
+				*NewParameter("password", NewType("string")),
+				*NewParameter("data", NewType("", ArrayTypeOption(NewType("byte")))),
+			),
+			ResultsFunctionOption(*NewParameter("", NewType("User")), *NewParameter("", NewType("error"))),
+			DocsFunctionOption(`@log(skip="data", redact="password")`),
+		),
+		NewInterfaceMethod("Count",
+			ParamsFunctionOption(*NewParameter("", NewType("string")), *NewParameter("", NewType("int"))),
+			ResultsFunctionOption(*NewParameter("", NewType("int"))),
+		),
+	})
+	tests := []struct {
+		name    string
+		iface   *Interface
+		options []LoggingOptions
+		want    string
+	}{
+		{
+			name:  "Should log the parameters respecting the annotations",
+			iface: iface,
+			want: `package test
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+type logging struct {
+	next   Service
+	logger *slog.Logger
+}
+
+func (l *logging) Login(ctx context.Context, user string, password string, data []byte) (r0 User, err error) {
+	defer func(begin time.Time) {
+		level := slog.LevelInfo
+		if err != nil {
+			level = slog.LevelError
+		}
+		l.logger.Log(ctx, level, "Login", "user", user, "password", "[REDACTED]", "took", time.Since(begin), "err", err)
+	}(time.Now())
+	r0, err = l.next.Login(ctx, user, password, data)
+	return r0, err
+}
+
+func (l *logging) Count(arg0 string, arg1 int) (r0 int) {
+	defer func(begin time.Time) {
+		l.logger.Log(context.Background(), slog.LevelInfo, "Count", "arg0", arg0, "arg1", arg1, "took", time.Since(begin))
+	}(time.Now())
+	r0 = l.next.Count(arg0, arg1)
+	return r0
+}
+
+// NewLogging returns a ServiceMiddleware that logs every call to Service.
+func NewLogging(logger *slog.Logger) ServiceMiddleware {
+	return func(next Service) Service {
+		return &logging{
+			logger: logger,
+			next:   next,
+		}
+	}
+}
+`,
+		},
+		{
+			name:    "Should only log the selected parameters and redact globally",
+			iface:   iface,
+			options: []LoggingOptions{ParamsLoggingOption("user", "arg1"), RedactLoggingOption("user")},
+			want: `package test
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+type logging struct {
+	next   Service
+	logger *slog.Logger
+}
+
+func (l *logging) Login(ctx context.Context, user string, password string, data []byte) (r0 User, err error) {
+	defer func(begin time.Time) {
+		level := slog.LevelInfo
+		if err != nil {
+			level = slog.LevelError
+		}
+		l.logger.Log(ctx, level, "Login", "user", "[REDACTED]", "took", time.Since(begin), "err", err)
+	}(time.Now())
+	r0, err = l.next.Login(ctx, user, password, data)
+	return r0, err
+}
+
+func (l *logging) Count(arg0 string, arg1 int) (r0 int) {
+	defer func(begin time.Time) {
+		l.logger.Log(context.Background(), slog.LevelInfo, "Count", "arg1", arg1, "took", time.Since(begin))
+	}(time.Now())
+	r0 = l.next.Count(arg0, arg1)
+	return r0
+}
+
+// NewLogging returns a ServiceMiddleware that logs every call to Service.
+func NewLogging(logger *slog.Logger) ServiceMiddleware {
+	return func(next Service) Service {
+		return &logging{
+			logger: logger,
+			next:   next,
+		}
+	}
+}
+`,
+		},
+		{
+			name: "Should not shadow the parameters in the deferred function",
+			iface: NewInterface("Service", []InterfaceMethod{NewInterfaceMethod("Do",
+				ParamsFunctionOption(*NewParameter("level", NewType("int")), *NewParameter("begin", NewType("string"))),
+				ResultsFunctionOption(*NewParameter("", NewType("error"))),
+			)}),
+			want: `package test
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+type logging struct {
+	next   Service
+	logger *slog.Logger
+}
+
+func (l *logging) Do(level int, begin string) (err error) {
+	defer func(begin1 time.Time) {
+		level1 := slog.LevelInfo
+		if err != nil {
+			level1 = slog.LevelError
+		}
+		l.logger.Log(context.Background(), level1, "Do", "level", level, "begin", begin, "took", time.Since(begin1), "err", err)
+	}(time.Now())
+	err = l.next.Do(level, begin)
+	return err
+}
+
+// NewLogging returns a ServiceMiddleware that logs every call to Service.
+func NewLogging(logger *slog.Logger) ServiceMiddleware {
+	return func(next Service) Service {
+		return &logging{
+			logger: logger,
+			next:   next,
+		}
+	}
+}
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code := NewLoggingMiddleware("logging", tt.iface, tt.options...)
+			if got := NewFile("test", code...).String(); got != tt.want {
+				t.Errorf("NewLoggingMiddleware() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestNewLoggingMiddleware_Compile compiles a logging middleware whose parameters have the names of the logged keys.
+func TestNewLoggingMiddleware_Compile(t *testing.T) {
+	iface := NewInterface("Service", []InterfaceMethod{
+		NewInterfaceMethod("OnError",
+			ParamsFunctionOption(
+				*NewParameter("ctx", NewType("Context", ImportTypeOption(*NewImport("", "context")))),
+				*NewParameter("err", NewType("error")),
+				*NewParameter("took", NewType("int")),
+			),
+			ResultsFunctionOption(*NewParameter("", NewType("error"))),
+		),
+	})
+	code := NewLoggingMiddleware("logging", iface)
+	got := NewFile("logging", code...).String()
+	want := `"OnError", "err", err, "took", took, "took1", time.Since(begin), "err1", err1)`
+	if !strings.Contains(got, want) {
+		t.Errorf("NewLoggingMiddleware() = %v, want it to contain %v", got, want)
+	}
+	runGeneratedTests(t, "logging", map[string]string{
+		"gen.go":      NewFile("logging", append([]Code{iface, NewMiddlewareType(iface)}, code...)...).String(),
+		"gen_test.go": loggingCompileTest,
+	})
+}
+
+const loggingCompileTest = `package logging
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+type service struct{}
+
+func (service) OnError(ctx context.Context, err error, took int) error { return errors.New("b") }
+
+func TestLogging(t *testing.T) {
+	var buf bytes.Buffer
+	s := NewLogging(slog.New(slog.NewJSONHandler(&buf, nil)))(service{})
+	if err := s.OnError(context.Background(), errors.New("a"), 1); err == nil {
+		t.Fatal("OnError() expected an error")
+	}
+	for _, want := range []string{` + "`" + `"level":"ERROR"` + "`" + `, ` + "`" + `"err":"a"` + "`" + `, ` + "`" + `"took":1` + "`" + `, ` + "`" + `"err1":"b"` + "`" + `} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("log = %s, want it to contain %s", buf.String(), want)
+		}
+	}
+}
+`
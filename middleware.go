@@ -0,0 +1,132 @@
+package code
+
+import (
+	"github.com/dave/jennifer/jen"
+)
+
+// MiddlewareMethod is passed to a MiddlewareHook for every interface method.
+type MiddlewareMethod struct {
+	// Method is the interface method, all its parameters and results are named
+	// so the hook code can use them (e.x ctx, arg1, r0, err).
+	Method InterfaceMethod
+
+	// Recv is the receiver name of the middleware method,
+	// it can be used to access the middleware fields (e.x jen.Id(m.Recv).Dot("logger")).
+	Recv string
+}
+
+// MiddlewareHook returns the code that is added before and after the call to the next implementation.
+//
+// The after code runs once the results are assigned so it can use and change them.
+type MiddlewareHook func(m MiddlewareMethod) (before []jen.Code, after []jen.Code)
+
+// MiddlewareOptions is used when you call NewMiddleware, it is a handy way to allow multiple configurations
+// for the generated middleware.
+type MiddlewareOptions func(o *middlewareOptions)
+
+type middlewareOptions struct {
+	fields []StructField
+	docs   []Comment
+}
+
+// FieldsMiddlewareOption adds fields to the middleware structure (e.x a logger),
+// the fields are also parameters of the middleware constructor.
+func FieldsMiddlewareOption(fields ...StructField) MiddlewareOptions {
+	return func(o *middlewareOptions) {
+		o.fields = append(o.fields, fields...)
+	}
+}
+
+// DocsMiddlewareOption sets the docs of the middleware constructor.
+func DocsMiddlewareOption(docs ...Comment) MiddlewareOptions {
+	return func(o *middlewareOptions) {
+		o.docs = docs
+	}
+}
+
+// NewMiddlewareType creates the middleware type of the interface (e.x type ServiceMiddleware func(Service) Service).
+func NewMiddlewareType(iface *Interface) *TypeDecl {
+	ifaceType := NewType(iface.Name)
+	return NewTypeDecl(
+		iface.Name+"Middleware",
+		NewType("", FunctionTypeOption(NewFunctionType(
+			ParamsFunctionOption(*NewParameter("", ifaceType)),
+			ResultsFunctionOption(*NewParameter("", ifaceType)),
+		))),
+		Comment(iface.Name+"Middleware is a chainable behavior modifier for "+iface.Name+"."),
+	)
+}
+
+// NewMiddleware creates a middleware for the interface.
+//
+// It returns a structure with the given name that wraps the next implementation of the interface,
+// every method runs the hook code before and after delegating the call to the next implementation.
+// Unnamed parameters are named arg{index}, unnamed results are named r{index} and err for a last error result,
+// generated names that are already used in the method get a number suffix (e.x err1).
+// A constructor New{Name} returning the middleware type (see NewMiddlewareType) is also returned,
+// its parameters are the fields added with FieldsMiddlewareOption.
+func NewMiddleware(name string, iface *Interface, hook MiddlewareHook, options ...MiddlewareOptions) []Code {
+	opts := &middlewareOptions{}
+	for _, o := range options {
+		o(opts)
+	}
+	recv := freeName(receiverName(name), "mw", iface.Methods)
+	st := NewStructWithFields(
+		name,
+		append(
+			[]StructField{*NewStructField("next", NewType(iface.Name))},
+			cloneFields(opts.fields)...,
+		),
+	)
+	for _, m := range iface.Methods {
+		params := namedParams(m.Params, "arg")
+		results := namedResults(params, m.Results)
+		before, after := hook(MiddlewareMethod{
+			Method: InterfaceMethod{
+				Name:    m.Name,
+				Params:  cloneParams(params),
+				Results: cloneParams(results),
+			},
+			Recv: recv,
+		})
+		call := jen.Id(recv).Dot("next").Dot(m.Name).Call(callArgs(params)...)
+		body := append([]jen.Code{}, before...)
+		if len(results) == 0 {
+			body = append(body, call)
+			body = append(body, after...)
+		} else {
+			body = append(body, jen.List(resultValues(results)...).Op("=").Add(call))
+			body = append(body, after...)
+			body = append(body, jen.Return(resultValues(results)...))
+		}
+		st.AddMethod(NewFunction(
+			m.Name,
+			ParamsFunctionOption(params...),
+			ResultsFunctionOption(results...),
+			BodyFunctionOption(body...),
+		), ReceiverNameMethodOption(recv))
+	}
+
+	values := jen.Dict{jen.Id("next"): jen.Id("next")}
+	var params []Parameter
+	for _, f := range opts.fields {
+		values[jen.Id(f.Name)] = jen.Id(f.Name)
+		params = append(params, *f.Parameter.Clone())
+	}
+	docs := opts.docs
+	if docs == nil {
+		docs = []Comment{Comment("New" + exportedName(name) + " returns a " + iface.Name + "Middleware that wraps " + iface.Name + " with " + name + ".")}
+	}
+	constructor := NewFunction(
+		"New"+exportedName(name),
+		ParamsFunctionOption(params...),
+		ResultsFunctionOption(*NewParameter("", NewType(iface.Name+"Middleware"))),
+		BodyFunctionOption(
+			jen.Return(jen.Func().Params(jen.Id("next").Id(iface.Name)).Id(iface.Name).Block(
+				jen.Return(jen.Op("&").Id(name).Values(values)),
+			)),
+		),
+		DocsFunctionOption(docs...),
+	)
+	return []Code{st, constructor}
+}
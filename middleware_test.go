@@ -0,0 +1,194 @@
+package code
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dave/jennifer/jen"
+)
+
+func TestNewMiddlewareType(t *testing.T) {
+	want := "// ServiceMiddleware is a chainable behavior modifier for Service.\ntype ServiceMiddleware func(Service) Service"
+	if got := NewMiddlewareType(NewInterface("Service", nil)).String(); got != want {
+		t.Errorf("NewMiddlewareType() = %v, want %v", got, want)
+	}
+}
+
+func TestNewMiddleware(t *testing.T) {
+	iface := NewInterface("Service", []InterfaceMethod{
+		NewInterfaceMethod("Get",
+			ParamsFunctionOption(
+				*NewParameter("", NewType("Context", ImportTypeOption(*NewImport("", "context")))),
+				*NewParameter("", NewType("string")),
+			),
+			ResultsFunctionOption(*NewParameter("", NewType("User")), *NewParameter("", NewType("error"))),
+		),
+		NewInterfaceMethod("Log",
+			ParamsFunctionOption(
+				*NewParameter("format", NewType("string")),
+				*NewParameter("args", NewType("interface{}", VariadicTypeOption())),
+			),
+		),
+	})
+	hook := func(m MiddlewareMethod) ([]jen.Code, []jen.Code) {
+		return []jen.Code{jen.Qual("fmt", "Fprintln").Call(jen.Id(m.Recv).Dot("w"), jen.Lit("calling "+m.Method.Name))},
+			[]jen.Code{jen.Qual("fmt", "Fprintln").Call(jen.Id(m.Recv).Dot("w"), jen.Lit("called "+m.Method.Name))}
+	}
+	tests := []struct {
+		name    string
+		options []MiddlewareOptions
+		want    string
+	}{
+		{
+			name: "Should create a middleware with auto generated names",
+			want: `package test
+
+import (
+	"context"
+	"fmt"
+)
+
+type printing struct {
+	next Service
+}
+
+func (p *printing) Get(arg0 context.Context, arg1 string) (r0 User, err error) {
+	fmt.Fprintln(p.w, "calling Get")
+	r0, err = p.next.Get(arg0, arg1)
+	fmt.Fprintln(p.w, "called Get")
+	return r0, err
+}
+
+func (p *printing) Log(format string, args ...interface{}) {
+	fmt.Fprintln(p.w, "calling Log")
+	p.next.Log(format, args...)
+	fmt.Fprintln(p.w, "called Log")
+}
+
+// NewPrinting returns a ServiceMiddleware that wraps Service with printing.
+func NewPrinting() ServiceMiddleware {
+	return func(next Service) Service {
+		return &printing{next: next}
+	}
+}
+`,
+		},
+		{
+			name: "Should create a middleware with extra fields and docs",
+			options: []MiddlewareOptions{
+				FieldsMiddlewareOption(*NewStructField("w", NewType("Writer", ImportTypeOption(*NewImport("", "io"))))),
+				DocsMiddlewareOption("NewPrinting prints every call."),
+			},
+			want: `package test
+
+import (
+	"context"
+	"fmt"
+	"io"
+)
+
+type printing struct {
+	next Service
+	w    io.Writer
+}
+
+func (p *printing) Get(arg0 context.Context, arg1 string) (r0 User, err error) {
+	fmt.Fprintln(p.w, "calling Get")
+	r0, err = p.next.Get(arg0, arg1)
+	fmt.Fprintln(p.w, "called Get")
+	return r0, err
+}
+
+func (p *printing) Log(format string, args ...interface{}) {
+	fmt.Fprintln(p.w, "calling Log")
+	p.next.Log(format, args...)
+	fmt.Fprintln(p.w, "called Log")
+}
+
+// NewPrinting prints every call.
+func NewPrinting(w io.Writer) ServiceMiddleware {
+	return func(next Service) Service {
+		return &printing{
+			next: next,
+			w:    w,
+		}
+	}
+}
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code := NewMiddleware("printing", iface, hook, tt.options...)
+			if got := NewFile("test", code...).String(); got != tt.want {
+				t.Errorf("NewMiddleware() = %v, want %v", got, tt.want)
+			}
+			if err := Implements(code[0].(*Struct), iface); err != nil {
+				t.Errorf("NewMiddleware() does not implement the interface: %v", err)
+			}
+		})
+	}
+}
+
+// TestNewMiddleware_Compile compiles a middleware for methods whose parameter names clash with the generated names.
+func TestNewMiddleware_Compile(t *testing.T) {
+	iface := NewInterface("Service", []InterfaceMethod{
+		NewInterfaceMethod("OnError",
+			ParamsFunctionOption(*NewParameter("err", NewType("error"))),
+			ResultsFunctionOption(*NewParameter("", NewType("error"))),
+		),
+		NewInterfaceMethod("Get",
+			ParamsFunctionOption(*NewParameter("", NewType("string")), *NewParameter("arg0", NewType("int"))),
+			ResultsFunctionOption(*NewParameter("", NewType("string")), *NewParameter("", NewType("error"))),
+		),
+	})
+	hook := func(m MiddlewareMethod) ([]jen.Code, []jen.Code) {
+		return []jen.Code{jen.Op("*").Id(m.Recv).Dot("calls").Op("++")}, nil
+	}
+	code := NewMiddleware("counting", iface, hook, FieldsMiddlewareOption(*NewStructField("calls", NewType("int", PointerTypeOption()))))
+	got := NewFile("middleware", code...).String()
+	for _, want := range []string{
+		"func (c *counting) OnError(err error) (err1 error) {",
+		"func (c *counting) Get(arg01 string, arg0 int) (r0 string, err error) {",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("NewMiddleware() = %v, want it to contain %v", got, want)
+		}
+	}
+	runGeneratedTests(t, "middleware", map[string]string{
+		// the interface is written by hand because go does not allow mixing named and unnamed parameters.
+		"service.go":  "package middleware\n\ntype Service interface {\n\tOnError(err error) error\n\tGet(string, int) (string, error)\n}\n",
+		"gen.go":      NewFile("middleware", append([]Code{NewMiddlewareType(iface)}, code...)...).String(),
+		"gen_test.go": middlewareCompileTest,
+	})
+}
+
+const middlewareCompileTest = `package middleware
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+)
+
+type service struct{}
+
+func (service) OnError(err error) error { return err }
+
+func (service) Get(s string, n int) (string, error) { return s + strconv.Itoa(n), nil }
+
+func TestMiddleware(t *testing.T) {
+	calls := 0
+	s := NewCounting(&calls)(service{})
+	want := errors.New("a")
+	if err := s.OnError(want); err != want {
+		t.Errorf("OnError() = %v, want %v", err, want)
+	}
+	if got, err := s.Get("a", 1); got != "a1" || err != nil {
+		t.Errorf("Get() = %v, %v", got, err)
+	}
+	if calls != 2 {
+		t.Errorf("calls = %v, want 2", calls)
+	}
+}
+`
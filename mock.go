@@ -0,0 +1,172 @@
+package code
+
+import (
+	"fmt"
+
+	"github.com/dave/jennifer/jen"
+)
+
+// NewMock creates a mock implementation of the interface that only uses the standard library.
+//
+// For every interface method (e.x Get) the mock structure has:
+//   - a GetFunc field that is called by Get, if it is nil Get returns the zero values of its results
+//   - a GetCalls method that returns the arguments of every call to Get
+//   - a GetCallCount method that returns the number of calls to Get
+//
+// Calls are recorded under a mutex so the mock can be used concurrently, Reset clears all recorded calls.
+// Unnamed parameters are named arg{index} and variadic parameters are recorded as slices.
+// The returned code is the mock structure with its methods and a compile-time assertion that it implements the interface.
+// The mock of a generic interface has the same type parameters (e.x MockRepository[T any]).
+// An error is returned if a generated field or method (e.x Reset, GetFunc or GetCalls) has the name of an interface method
+// or of another generated field or method.
+func NewMock(name string, iface *Interface) ([]Code, error) {
+	if err := mockNameClash(iface); err != nil {
+		return nil, err
+	}
+	recv := freeName("m", "mock", iface.Methods)
+	st := NewStruct(
+		name,
+		Comment(name+" is a mock implementation of "+iface.Name+"."),
+		Comment("Set the <Method>Func fields to control what the methods return."),
+	)
+	st.TypeParams = cloneParams(iface.TypeParams)
+	var callFields []StructField
+	var reset []jen.Code
+	var accessors []*Function
+	for _, m := range iface.Methods {
+		params := namedParams(m.Params, "arg")
+		callsField := unexportedName(m.Name) + "Calls"
+		funcField := m.Name + "Func"
+		callType := mockCallType(params)
+		callsType := NewType("", ArrayTypeOption(callType))
+
+		funcDoc := Comment(funcField + " is called by " + m.Name + ", if it is nil " + m.Name + " returns the zero values.")
+		if len(m.Results) == 0 {
+			funcDoc = Comment(funcField + " is called by " + m.Name + ", if it is nil " + m.Name + " does nothing.")
+		}
+		st.Fields = append(st.Fields, *NewStructField(
+			funcField,
+			NewType("", FunctionTypeOption(NewFunctionType(
+				ParamsFunctionOption(params...),
+				ResultsFunctionOption(cloneParams(m.Results)...),
+			))),
+			funcDoc,
+		))
+		callFields = append(callFields, *NewStructField(callsField, callsType))
+		reset = append(reset, jen.Id(recv).Dot(callsField).Op("=").Nil())
+
+		call := jen.Id(recv).Dot(funcField).Call(callArgs(params)...)
+		body := []jen.Code{
+			jen.Id(recv).Dot("mu").Dot("Lock").Call(),
+			jen.Id(recv).Dot(callsField).Op("=").Append(
+				jen.Id(recv).Dot(callsField),
+				callType.Code().Values(mockCallValues(params)),
+			),
+			jen.Id(recv).Dot("mu").Dot("Unlock").Call(),
+		}
+		if len(m.Results) == 0 {
+			body = append(body, jen.If(jen.Id(recv).Dot(funcField).Op("!=").Nil()).Block(call))
+		} else {
+			body = append(
+				body,
+				jen.If(jen.Id(recv).Dot(funcField).Op("==").Nil()).Block(zeroReturn(params, m.Results, nil)...),
+				jen.Return(call),
+			)
+		}
+		st.AddMethod(NewFunction(
+			m.Name,
+			ParamsFunctionOption(params...),
+			ResultsFunctionOption(cloneParams(m.Results)...),
+			BodyFunctionOption(body...),
+		), ReceiverNameMethodOption(recv))
+
+		accessors = append(accessors, NewFunction(
+			m.Name+"Calls",
+			ResultsFunctionOption(*NewParameter("", callsType)),
+			BodyFunctionOption(
+				jen.Id(recv).Dot("mu").Dot("Lock").Call(),
+				jen.Defer().Id(recv).Dot("mu").Dot("Unlock").Call(),
+				jen.Return(jen.Append(callsType.Code().Values(), jen.Id(recv).Dot(callsField).Op("..."))),
+			),
+			DocsFunctionOption(Comment(m.Name+"Calls returns the arguments of all the calls to "+m.Name+".")),
+		), NewFunction(
+			m.Name+"CallCount",
+			ResultsFunctionOption(*NewParameter("", NewType("int"))),
+			BodyFunctionOption(
+				jen.Id(recv).Dot("mu").Dot("Lock").Call(),
+				jen.Defer().Id(recv).Dot("mu").Dot("Unlock").Call(),
+				jen.Return(jen.Len(jen.Id(recv).Dot(callsField))),
+			),
+			DocsFunctionOption(Comment(m.Name+"CallCount returns the number of calls to "+m.Name+".")),
+		))
+	}
+	st.Fields = append(st.Fields, *NewStructField("mu", NewType("Mutex", ImportTypeOption(Import{Path: "sync"}))))
+	st.Fields = append(st.Fields, callFields...)
+
+	for _, a := range accessors {
+		st.AddMethod(a, ReceiverNameMethodOption(recv))
+	}
+	st.AddMethod(NewFunction(
+		"Reset",
+		BodyFunctionOption(append([]jen.Code{
+			jen.Id(recv).Dot("mu").Dot("Lock").Call(),
+			jen.Defer().Id(recv).Dot("mu").Dot("Unlock").Call(),
+		}, reset...)...),
+		DocsFunctionOption("Reset clears all the recorded calls."),
+	), ReceiverNameMethodOption(recv))
+
+	return []Code{st, NewInterfaceAssertion(
+		iface,
+		NewType(name, PointerTypeOption(), TypeArgsTypeOption(typeParamArgs(st.TypeParams)...)),
+	)}, nil
+}
+
+// mockNameClash returns an error if the fields and methods generated for the mock of the interface
+// use the name of an interface method or the same name twice.
+func mockNameClash(iface *Interface) error {
+	names := map[string]string{"mu": "the mu field of the mock", "Reset": "the Reset method of the mock"}
+	for _, m := range iface.Methods {
+		if other, ok := names[m.Name]; ok {
+			return fmt.Errorf("the method %s of %s clashes with %s", m.Name, iface.Name, other)
+		}
+		names[m.Name] = "the method " + m.Name
+	}
+	for _, m := range iface.Methods {
+		generated := []struct{ kind, name string }{
+			{"field", m.Name + "Func"},
+			{"field", unexportedName(m.Name) + "Calls"},
+			{"method", m.Name + "Calls"},
+			{"method", m.Name + "CallCount"},
+		}
+		for _, g := range generated {
+			if other, ok := names[g.name]; ok {
+				return fmt.Errorf("the %s %s generated for the method %s of %s clashes with %s", g.kind, g.name, m.Name, iface.Name, other)
+			}
+			names[g.name] = "the " + g.kind + " " + g.name
+		}
+	}
+	return nil
+}
+
+// mockCallType returns the struct type used to record the arguments of a call,
+// variadic parameters are recorded as slices.
+func mockCallType(params []Parameter) Type {
+	var fields []StructField
+	for _, p := range params {
+		tp := p.Type.Clone()
+		if tp.Variadic {
+			tp.Variadic = false
+			tp = NewType("", ArrayTypeOption(tp))
+		}
+		fields = append(fields, *NewStructField(exportedName(p.Name), tp))
+	}
+	return NewType("", StructTypeOption(*NewStructType(fields...)))
+}
+
+func mockCallValues(params []Parameter) jen.Dict {
+	values := jen.Dict{}
+	for _, p := range params {
+		values[jen.Id(exportedName(p.Name))] = jen.Id(p.Name)
+	}
+	return values
+}
@@ -0,0 +1,271 @@
+package code
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNewMock(t *testing.T) {
+	iface := NewInterface("Service", []InterfaceMethod{
+		NewInterfaceMethod("Get",
+			ParamsFunctionOption(
+				*NewParameter("", NewType("Context", ImportTypeOption(*NewImport("ctx", "context")))),
+				*NewParameter("", NewType("string")),
+			),
+			ResultsFunctionOption(*NewParameter("", NewType("User")), *NewParameter("", NewType("error"))),
+		),
+		NewInterfaceMethod("Log",
+			ParamsFunctionOption(
+				*NewParameter("m", NewType("string")),
+				*NewParameter("args", NewType("interface{}", VariadicTypeOption())),
+			),
+		),
+	})
+	want := `package test
+
+import (
+	ctx "context"
+	"sync"
+)
+
+// MockService is a mock implementation of Service.
+// Set the <Method>Func fields to control what the methods return.
+type MockService struct {
+	// GetFunc is called by Get, if it is nil Get returns the zero values.
+	GetFunc func(arg0 ctx.Context, arg1 string) (User, error)
+	// LogFunc is called by Log, if it is nil Log does nothing.
+	LogFunc  func(m string, args ...interface{})
+	mu       sync.Mutex
+	getCalls []struct {
+		Arg0 ctx.Context
+		Arg1 string
+	}
+	logCalls []struct {
+		M    string
+		Args []interface{}
+	}
+}
+
+func (mock *MockService) Get(arg0 ctx.Context, arg1 string) (User, error) {
+	mock.mu.Lock()
+	mock.getCalls = append(mock.getCalls, struct {
+		Arg0 ctx.Context
+		Arg1 string
+	}{
+		Arg0: arg0,
+		Arg1: arg1,
+	})
+	mock.mu.Unlock()
+	if mock.GetFunc == nil {
+		var r0 User
+		return r0, nil
+	}
+	return mock.GetFunc(arg0, arg1)
+}
+
+func (mock *MockService) Log(m string, args ...interface{}) {
+	mock.mu.Lock()
+	mock.logCalls = append(mock.logCalls, struct {
+		M    string
+		Args []interface{}
+	}{
+		Args: args,
+		M:    m,
+	})
+	mock.mu.Unlock()
+	if mock.LogFunc != nil {
+		mock.LogFunc(m, args...)
+	}
+}
+
+// GetCalls returns the arguments of all the calls to Get.
+func (mock *MockService) GetCalls() []struct {
+	Arg0 ctx.Context
+	Arg1 string
+} {
+	mock.mu.Lock()
+	defer mock.mu.Unlock()
+	return append([]struct {
+		Arg0 ctx.Context
+		Arg1 string
+	}{}, mock.getCalls...)
+}
+
+// GetCallCount returns the number of calls to Get.
+func (mock *MockService) GetCallCount() int {
+	mock.mu.Lock()
+	defer mock.mu.Unlock()
+	return len(mock.getCalls)
+}
+
+// LogCalls returns the arguments of all the calls to Log.
+func (mock *MockService) LogCalls() []struct {
+	M    string
+	Args []interface{}
+} {
+	mock.mu.Lock()
+	defer mock.mu.Unlock()
+	return append([]struct {
+		M    string
+		Args []interface{}
+	}{}, mock.logCalls...)
+}
+
+// LogCallCount returns the number of calls to Log.
+func (mock *MockService) LogCallCount() int {
+	mock.mu.Lock()
+	defer mock.mu.Unlock()
+	return len(mock.logCalls)
+}
+
+// Reset clears all the recorded calls.
+func (mock *MockService) Reset() {
+	mock.mu.Lock()
+	defer mock.mu.Unlock()
+	mock.getCalls = nil
+	mock.logCalls = nil
+}
+
+var _ Service = (*MockService)(nil)
+`
+	code, err := NewMock("MockService", iface)
+	if err != nil {
+		t.Fatalf("NewMock() error = %v", err)
+	}
+	if got := NewFile("test", code...).String(); got != want {
+		t.Errorf("NewMock() = %v, want %v", got, want)
+	}
+	if err := Implements(code[0].(*Struct), iface); err != nil {
+		t.Errorf("NewMock() does not implement the interface: %v", err)
+	}
+}
+
+func TestNewMock_NameClash(t *testing.T) {
+	tests := []struct {
+		name    string
+		methods []InterfaceMethod
+	}{
+		{
+			name:    "Should return an error for a Reset method",
+			methods: []InterfaceMethod{NewInterfaceMethod("Reset")},
+		},
+		{
+			name:    "Should return an error for a method with the name of a call accessor",
+			methods: []InterfaceMethod{NewInterfaceMethod("Get"), NewInterfaceMethod("GetCalls")},
+		},
+		{
+			name:    "Should return an error for a method with the name of a func field",
+			methods: []InterfaceMethod{NewInterfaceMethod("Get"), NewInterfaceMethod("GetFunc")},
+		},
+		{
+			name:    "Should return an error for unexported methods with the name of their calls field",
+			methods: []InterfaceMethod{NewInterfaceMethod("get")},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewMock("MockService", NewInterface("Service", tt.methods)); err == nil {
+				t.Error("NewMock() expected an error")
+			}
+		})
+	}
+}
+
+// TestNewMock_Compile compiles the generated mock and checks that it records the calls.
+func TestNewMock_Compile(t *testing.T) {
+	iface := NewInterface("Service", []InterfaceMethod{
+		NewInterfaceMethod("Get",
+			ParamsFunctionOption(*NewParameter("m", NewType("string")), *NewParameter("mock", NewType("int"))),
+			ResultsFunctionOption(*NewParameter("", NewType("int"))),
+		),
+		NewInterfaceMethod("Do",
+			ParamsFunctionOption(
+				*NewParameter("", NewType("Context", ImportTypeOption(*NewImport("", "context")))),
+				*NewParameter("", NewType("string", VariadicTypeOption())),
+			),
+			ResultsFunctionOption(*NewParameter("", NewType("error"))),
+		),
+	})
+	code, err := NewMock("MockService", iface)
+	if err != nil {
+		t.Fatalf("NewMock() error = %v", err)
+	}
+	runGeneratedTests(t, "mock", map[string]string{
+		"gen.go":      NewFile("mock", append([]Code{iface}, code...)...).String(),
+		"gen_test.go": mockCompileTest,
+	})
+}
+
+const mockCompileTest = `package mock
+
+import (
+	"context"
+	"testing"
+)
+
+func TestMock(t *testing.T) {
+	m := &MockService{GetFunc: func(m string, mock int) int { return len(m) + mock }}
+	if got := m.Get("ab", 1); got != 3 {
+		t.Errorf("Get() = %v, want 3", got)
+	}
+	if err := m.Do(context.Background(), "a", "b"); err != nil {
+		t.Errorf("Do() error = %v", err)
+	}
+	if calls := m.DoCalls(); len(calls) != 1 || len(calls[0].Arg1) != 2 {
+		t.Errorf("DoCalls() = %v", calls)
+	}
+	m.Reset()
+	if m.GetCallCount() != 0 {
+		t.Errorf("GetCallCount() = %v after Reset", m.GetCallCount())
+	}
+}
+`
+
+// TestNewMock_Generic compiles the mock of a generic interface.
+func TestNewMock_Generic(t *testing.T) {
+	iface := NewInterface("Repository", []InterfaceMethod{
+		NewInterfaceMethod("Get",
+			ParamsFunctionOption(*NewParameter("id", NewType("K"))),
+			ResultsFunctionOption(*NewParameter("", NewType("T")), *NewParameter("", NewType("error"))),
+		),
+		NewInterfaceMethod("Put", ParamsFunctionOption(*NewParameter("items", NewType("T", VariadicTypeOption())))),
+	})
+	iface.TypeParams = []Parameter{*NewParameter("T", NewType("any")), *NewParameter("K", NewType("comparable"))}
+	code, err := NewMock("MockRepository", iface)
+	if err != nil {
+		t.Fatalf("NewMock() error = %v", err)
+	}
+	got := NewFile("mock", code...).String()
+	for _, want := range []string{
+		"type MockRepository[T any, K comparable] struct {",
+		"func (m *MockRepository[T, K]) Get(id K) (T, error) {",
+		"func _[T any, K comparable]() {\n\tvar _ Repository[T, K] = (*MockRepository[T, K])(nil)\n}",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("NewMock() = %v, want it to contain %v", got, want)
+		}
+	}
+	if err := Implements(code[0].(*Struct), iface); err != nil {
+		t.Errorf("NewMock() does not implement the interface: %v", err)
+	}
+	runGeneratedTests(t, "mock", map[string]string{
+		"gen.go":      NewFile("mock", append([]Code{iface}, code...)...).String(),
+		"gen_test.go": mockGenericTest,
+	})
+}
+
+const mockGenericTest = `package mock
+
+import "testing"
+
+func TestMock(t *testing.T) {
+	m := &MockRepository[string, int]{GetFunc: func(id int) (string, error) { return "a", nil }}
+	if got, err := m.Get(1); got != "a" || err != nil {
+		t.Errorf("Get() = %v, %v", got, err)
+	}
+	m.Put("a", "b")
+	if calls := m.PutCalls(); len(calls) != 1 || len(calls[0].Items) != 2 {
+		t.Errorf("PutCalls() = %v", calls)
+	}
+}
+`
@@ -0,0 +1,292 @@
+package code
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/dave/jennifer/jen"
+)
+
+// Placeholder is the style of the query parameter placeholders used by a database driver.
+type Placeholder int
+
+const (
+	// QuestionPlaceholder uses ? for every parameter (e.x MySQL and SQLite).
+	QuestionPlaceholder Placeholder = iota
+
+	// DollarPlaceholder uses numbered parameters starting from $1 (e.x PostgreSQL).
+	DollarPlaceholder
+)
+
+// RepositoryOptions is used when you call NewRepository, it is a handy way to allow multiple configurations
+// for the generated repository.
+type RepositoryOptions func(o *repositoryOptions)
+
+type repositoryOptions struct {
+	tagKey      string
+	table       string
+	placeholder Placeholder
+}
+
+// TagRepositoryOption sets the tag key of the column names, the default key is db.
+func TagRepositoryOption(key string) RepositoryOptions {
+	return func(o *repositoryOptions) {
+		o.tagKey = key
+	}
+}
+
+// TableRepositoryOption sets the name of the database table, the default name is the snake case name
+// of the structure with an s suffix (e.x OrderItem is stored in order_items).
+func TableRepositoryOption(table string) RepositoryOptions {
+	return func(o *repositoryOptions) {
+		o.table = table
+	}
+}
+
+// PlaceholderRepositoryOption sets the placeholder style of the queries, the default style is QuestionPlaceholder.
+func PlaceholderRepositoryOption(placeholder Placeholder) RepositoryOptions {
+	return func(o *repositoryOptions) {
+		o.placeholder = placeholder
+	}
+}
+
+// NewRepository creates the {Name}Repository interface of the structure and its database/sql implementation.
+//
+// The interface has the Get, List, Create, Update and Delete methods, the implementation is created with
+// NewSQL{Name}Repository(db *sql.DB) {Name}Repository.
+// The columns are set in the db tags of the fields (e.x `db:"email"`), fields without a db tag
+// or with `db:"-"` are not stored. One of the columns must be the primary key (e.x `db:"id,pk"`),
+// the primary key can also be generated by the database (e.x `db:"id,pk,auto"`), in that case Create does not
+// insert it and sets it from the result (LastInsertId with QuestionPlaceholder and RETURNING with DollarPlaceholder).
+// Get returns sql.ErrNoRows if there is no row with the primary key.
+// An error is returned if the primary key is missing or if a column can not be stored (see AddSQL).
+func NewRepository(st *Struct, options ...RepositoryOptions) ([]Code, error) {
+	opts := &repositoryOptions{
+		tagKey: "db",
+		table:  snakeName(st.Name) + "s",
+	}
+	for _, o := range options {
+		o(opts)
+	}
+	columns, err := sqlColumns(st, opts.tagKey)
+	if err != nil {
+		return nil, err
+	}
+	var pk *sqlColumn
+	var others []sqlColumn
+	for i, c := range columns {
+		if !c.hasOption("pk") {
+			others = append(others, c)
+			continue
+		}
+		if pk != nil {
+			return nil, fmt.Errorf("the structure %s has more than one primary key column (%s and %s)", st.Name, pk.name, c.name)
+		}
+		pk = &columns[i]
+	}
+	if pk == nil {
+		return nil, fmt.Errorf("the structure %s has no primary key column (e.x `%s:\"id,pk\"`)", st.Name, opts.tagKey)
+	}
+	if len(others) == 0 {
+		return nil, fmt.Errorf("the structure %s has no columns besides the primary key", st.Name)
+	}
+	auto := pk.hasOption("auto")
+	if auto && opts.placeholder == QuestionPlaceholder && basicKind(pk.tp) != "int" && basicKind(pk.tp) != "uint" {
+		return nil, fmt.Errorf("the auto primary key %s must be an integer to be set from LastInsertId", pk.field)
+	}
+	// the names used by the generated code, List also uses the plural of the value name (e.x orderItems).
+	used := map[string]bool{"ctx": true, "r": true, "err": true, "rows": true, "res": true, "lastID": true}
+	value := paramName(st.Name)
+	if used[value] || used[value+"s"] {
+		value = "value"
+	}
+	list := value + "s"
+	used[value], used[list] = true, true
+	key := paramName(pk.field)
+	if used[key] {
+		key = "key"
+	}
+
+	name := exportedName(st.Name) + "Repository"
+	impl := "sql" + name
+	ctx := *NewParameter("ctx", NewType("Context", ImportTypeOption(Import{Path: "context"})))
+	keyParam := *NewParameter(key, pk.tp.Clone())
+	valueParam := *NewParameter(value, NewType(st.Name, PointerTypeOption()))
+	errResult := *NewParameter("", NewType("error"))
+	signatures := map[string][]FunctionOptions{
+		"Get": {
+			ParamsFunctionOption(ctx, keyParam),
+			ResultsFunctionOption(*NewParameter("", NewType(st.Name, PointerTypeOption())), errResult),
+		},
+		"List": {
+			ParamsFunctionOption(ctx),
+			ResultsFunctionOption(*NewParameter("", NewType("", ArrayTypeOption(NewType(st.Name, PointerTypeOption())))), errResult),
+		},
+		"Create": {ParamsFunctionOption(ctx, valueParam), ResultsFunctionOption(errResult)},
+		"Update": {ParamsFunctionOption(ctx, valueParam), ResultsFunctionOption(errResult)},
+		"Delete": {ParamsFunctionOption(ctx, keyParam), ResultsFunctionOption(errResult)},
+	}
+	methodNames := []string{"Get", "List", "Create", "Update", "Delete"}
+	docs := map[string]string{
+		"Get":    "Get returns the " + st.Name + " with the " + key + ".",
+		"List":   "List returns all the " + st.Name + " records.",
+		"Create": "Create stores a new " + st.Name + ".",
+		"Update": "Update stores the changes of the " + st.Name + ".",
+		"Delete": "Delete removes the " + st.Name + " with the " + key + ".",
+	}
+
+	placeholder := func(i int) string {
+		if opts.placeholder == DollarPlaceholder {
+			return "$" + strconv.Itoa(i)
+		}
+		return "?"
+	}
+	names := func(columns []sqlColumn) string {
+		var s []string
+		for _, c := range columns {
+			s = append(s, c.name)
+		}
+		return strings.Join(s, ", ")
+	}
+	fields := func(v string, columns []sqlColumn, ptr bool) []jen.Code {
+		var c []jen.Code
+		for _, col := range columns {
+			if ptr {
+				c = append(c, jen.Op("&").Id(v).Dot(col.field))
+			} else {
+				c = append(c, jen.Id(v).Dot(col.field))
+			}
+		}
+		return c
+	}
+	db := jen.Id("r").Dot("db")
+	selectQuery := "SELECT " + names(columns) + " FROM " + opts.table
+
+	inserted := columns
+	if auto {
+		inserted = others
+	}
+	var values []string
+	for i := range inserted {
+		values = append(values, placeholder(i+1))
+	}
+	insertQuery := "INSERT INTO " + opts.table + " (" + names(inserted) + ") VALUES (" + strings.Join(values, ", ") + ")"
+	var create []jen.Code
+	switch {
+	case auto && opts.placeholder == DollarPlaceholder:
+		create = []jen.Code{
+			jen.Return(db.Clone().Dot("QueryRowContext").Call(
+				append([]jen.Code{jen.Id("ctx"), jen.Lit(insertQuery + " RETURNING " + pk.name)}, fields(value, inserted, false)...)...,
+			).Dot("Scan").Call(jen.Op("&").Id(value).Dot(pk.field))),
+		}
+	case auto:
+		create = []jen.Code{
+			jen.List(jen.Id("res"), jen.Err()).Op(":=").Add(db.Clone()).Dot("ExecContext").Call(
+				append([]jen.Code{jen.Id("ctx"), jen.Lit(insertQuery)}, fields(value, inserted, false)...)...,
+			),
+			jen.If(jen.Err().Op("!=").Nil()).Block(jen.Return(jen.Err())),
+			jen.List(jen.Id("lastID"), jen.Err()).Op(":=").Id("res").Dot("LastInsertId").Call(),
+			jen.If(jen.Err().Op("!=").Nil()).Block(jen.Return(jen.Err())),
+			jen.Id(value).Dot(pk.field).Op("=").Add(convert(pk.tp.Qualifier, NewType("int64"), jen.Id("lastID"))),
+			jen.Return(jen.Nil()),
+		}
+	default:
+		create = []jen.Code{
+			jen.List(jen.Id("_"), jen.Err()).Op(":=").Add(db.Clone()).Dot("ExecContext").Call(
+				append([]jen.Code{jen.Id("ctx"), jen.Lit(insertQuery)}, fields(value, inserted, false)...)...,
+			),
+			jen.Return(jen.Err()),
+		}
+	}
+
+	var set []string
+	for i, c := range others {
+		set = append(set, c.name+" = "+placeholder(i+1))
+	}
+	updateQuery := "UPDATE " + opts.table + " SET " + strings.Join(set, ", ") + " WHERE " + pk.name + " = " + placeholder(len(others)+1)
+
+	bodies := map[string][]jen.Code{
+		"Get": {
+			jen.Var().Id(value).Id(st.Name),
+			jen.If(
+				jen.Err().Op(":=").Add(db.Clone()).Dot("QueryRowContext").Call(
+					jen.Id("ctx"), jen.Lit(selectQuery+" WHERE "+pk.name+" = "+placeholder(1)), jen.Id(key),
+				).Dot("Scan").Call(fields(value, columns, true)...),
+				jen.Err().Op("!=").Nil(),
+			).Block(jen.Return(jen.Nil(), jen.Err())),
+			jen.Return(jen.Op("&").Id(value), jen.Nil()),
+		},
+		"List": {
+			jen.List(jen.Id("rows"), jen.Err()).Op(":=").Add(db.Clone()).Dot("QueryContext").Call(jen.Id("ctx"), jen.Lit(selectQuery)),
+			jen.If(jen.Err().Op("!=").Nil()).Block(jen.Return(jen.Nil(), jen.Err())),
+			jen.Defer().Id("rows").Dot("Close").Call(),
+			jen.Var().Id(list).Index().Op("*").Id(st.Name),
+			jen.For(jen.Id("rows").Dot("Next").Call()).Block(
+				jen.Var().Id(value).Id(st.Name),
+				jen.If(
+					jen.Err().Op(":=").Id("rows").Dot("Scan").Call(fields(value, columns, true)...),
+					jen.Err().Op("!=").Nil(),
+				).Block(jen.Return(jen.Nil(), jen.Err())),
+				jen.Id(list).Op("=").Append(jen.Id(list), jen.Op("&").Id(value)),
+			),
+			jen.Return(jen.Id(list), jen.Id("rows").Dot("Err").Call()),
+		},
+		"Create": create,
+		"Update": {
+			jen.List(jen.Id("_"), jen.Err()).Op(":=").Add(db.Clone()).Dot("ExecContext").Call(
+				append(append([]jen.Code{jen.Id("ctx"), jen.Lit(updateQuery)}, fields(value, others, false)...), jen.Id(value).Dot(pk.field))...,
+			),
+			jen.Return(jen.Err()),
+		},
+		"Delete": {
+			jen.List(jen.Id("_"), jen.Err()).Op(":=").Add(db.Clone()).Dot("ExecContext").Call(
+				jen.Id("ctx"), jen.Lit("DELETE FROM "+opts.table+" WHERE "+pk.name+" = "+placeholder(1)), jen.Id(key),
+			),
+			jen.Return(jen.Err()),
+		},
+	}
+	var methods []InterfaceMethod
+	repo := NewStructWithFields(
+		impl,
+		[]StructField{*NewStructField("db", NewType("DB", ImportTypeOption(Import{Path: "database/sql"}), PointerTypeOption()))},
+		Comment(impl+" is the database/sql implementation of "+name+"."),
+	)
+	for _, m := range methodNames {
+		methods = append(methods, NewInterfaceMethod(m, append(signatures[m], DocsFunctionOption(Comment(docs[m])))...))
+		doc := docs[m]
+		if m == "Get" {
+			doc = "Get returns the " + st.Name + " with the " + key + ", it returns sql.ErrNoRows if there is no " + st.Name + " with the " + key + "."
+		}
+		repo.AddMethod(
+			NewFunction(m, append(signatures[m], BodyFunctionOption(bodies[m]...), DocsFunctionOption(Comment(doc)))...),
+			ReceiverNameMethodOption("r"),
+		)
+	}
+	iface := NewInterface(name, methods, Comment(name+" stores the "+st.Name+" records."))
+	constructor := NewFunction(
+		"NewSQL"+name,
+		ParamsFunctionOption(*NewParameter("db", NewType("DB", ImportTypeOption(Import{Path: "database/sql"}), PointerTypeOption()))),
+		ResultsFunctionOption(*NewParameter("", NewType(name))),
+		BodyFunctionOption(jen.Return(jen.Op("&").Id(impl).Values(jen.Dict{jen.Id("db"): jen.Id("db")}))),
+		DocsFunctionOption(Comment("NewSQL"+name+" creates a new "+name+" that stores the records in the "+opts.table+" table of the db.")),
+	)
+	return []Code{iface, repo, constructor}, nil
+}
+
+// snakeName returns the snake case name (e.x OrderItem is order_item and HTTPRequest is http_request).
+func snakeName(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) {
+			prev := runes[i-1]
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && i+1 < len(runes) && unicode.IsLower(runes[i+1])) {
+				b.WriteByte('_')
+			}
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String()
+}
@@ -0,0 +1,387 @@
+package code
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNewRepository(t *testing.T) {
+	db := func(v string) *FieldTags { return NewFieldTags("db", v) }
+	tests := []struct {
+		name    string
+		fields  []StructField
+		options []RepositoryOptions
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "Should create the repository",
+			fields: []StructField{
+				*NewStructFieldWithTag("Key", NewType("string"), db("key,pk")),
+				*NewStructFieldWithTag("Count", NewType("int"), db("count")),
+				*NewStructField("Other", NewType("string")),
+			},
+			options: []RepositoryOptions{PlaceholderRepositoryOption(DollarPlaceholder)},
+			want: `package test
+
+import (
+	"context"
+	"database/sql"
+)
+
+// OrderItemRepository stores the OrderItem records.
+type OrderItemRepository interface {
+	// Get returns the OrderItem with the key.
+	Get(ctx context.Context, key string) (*OrderItem, error)
+	// List returns all the OrderItem records.
+	List(ctx context.Context) ([]*OrderItem, error)
+	// Create stores a new OrderItem.
+	Create(ctx context.Context, orderItem *OrderItem) error
+	// Update stores the changes of the OrderItem.
+	Update(ctx context.Context, orderItem *OrderItem) error
+	// Delete removes the OrderItem with the key.
+	Delete(ctx context.Context, key string) error
+}
+
+// sqlOrderItemRepository is the database/sql implementation of OrderItemRepository.
+type sqlOrderItemRepository struct {
+	db *sql.DB
+}
+
+// Get returns the OrderItem with the key, it returns sql.ErrNoRows if there is no OrderItem with the key.
+func (r *sqlOrderItemRepository) Get(ctx context.Context, key string) (*OrderItem, error) {
+	var orderItem OrderItem
+	if err := r.db.QueryRowContext(ctx, "SELECT key, count FROM order_items WHERE key = $1", key).Scan(&orderItem.Key, &orderItem.Count); err != nil {
+		return nil, err
+	}
+	return &orderItem, nil
+}
+
+// List returns all the OrderItem records.
+func (r *sqlOrderItemRepository) List(ctx context.Context) ([]*OrderItem, error) {
+	rows, err := r.db.QueryContext(ctx, "SELECT key, count FROM order_items")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var orderItems []*OrderItem
+	for rows.Next() {
+		var orderItem OrderItem
+		if err := rows.Scan(&orderItem.Key, &orderItem.Count); err != nil {
+			return nil, err
+		}
+		orderItems = append(orderItems, &orderItem)
+	}
+	return orderItems, rows.Err()
+}
+
+// Create stores a new OrderItem.
+func (r *sqlOrderItemRepository) Create(ctx context.Context, orderItem *OrderItem) error {
+	_, err := r.db.ExecContext(ctx, "INSERT INTO order_items (key, count) VALUES ($1, $2)", orderItem.Key, orderItem.Count)
+	return err
+}
+
+// Update stores the changes of the OrderItem.
+func (r *sqlOrderItemRepository) Update(ctx context.Context, orderItem *OrderItem) error {
+	_, err := r.db.ExecContext(ctx, "UPDATE order_items SET count = $1 WHERE key = $2", orderItem.Count, orderItem.Key)
+	return err
+}
+
+// Delete removes the OrderItem with the key.
+func (r *sqlOrderItemRepository) Delete(ctx context.Context, key string) error {
+	_, err := r.db.ExecContext(ctx, "DELETE FROM order_items WHERE key = $1", key)
+	return err
+}
+
+// NewSQLOrderItemRepository creates a new OrderItemRepository that stores the records in the order_items table of the db.
+func NewSQLOrderItemRepository(db *sql.DB) OrderItemRepository {
+	return &sqlOrderItemRepository{db: db}
+}
+`,
+		},
+		{
+			name: "Should return an error without a primary key",
+			fields: []StructField{
+				*NewStructFieldWithTag("Key", NewType("string"), db("key")),
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should return an error with two primary keys",
+			fields: []StructField{
+				*NewStructFieldWithTag("Key", NewType("string"), db("key,pk")),
+				*NewStructFieldWithTag("ID", NewType("int"), db("id,pk")),
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should return an error without other columns",
+			fields: []StructField{
+				*NewStructFieldWithTag("Key", NewType("string"), db("key,pk")),
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should return an error for auto primary keys that are not integers with QuestionPlaceholder",
+			fields: []StructField{
+				*NewStructFieldWithTag("Key", NewType("string"), db("key,pk,auto")),
+				*NewStructFieldWithTag("Count", NewType("int"), db("count")),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewRepository(NewStructWithFields("OrderItem", tt.fields), tt.options...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewRepository() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if s := NewFile("test", got...).String(); s != tt.want {
+				t.Errorf("NewRepository() = %v, want %v", s, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewRepository_RecordingDriver(t *testing.T) {
+	db := func(v string) *FieldTags { return NewFieldTags("db", v) }
+	user := NewStructWithFields("User", []StructField{
+		*NewStructFieldWithTag("ID", NewType("int64"), db("id,pk,auto")),
+		*NewStructFieldWithTag("Name", NewType("string"), db("name")),
+		*NewStructFieldWithTag("Email", NewType("NullString", ImportTypeOption(*NewImport("", "database/sql"))), db("email")),
+		*NewStructFieldWithTag("Age", NewType("int", PointerTypeOption()), db("age")),
+	})
+	item := NewStructWithFields("Item", []StructField{
+		*NewStructFieldWithTag("ID", NewType("uint32"), db("id,pk,auto")),
+		*NewStructFieldWithTag("Title", NewType("string"), db("title")),
+	})
+	// the plural of row is the name of the rows variable of List.
+	row := NewStructWithFields("Row", []StructField{
+		*NewStructFieldWithTag("ID", NewType("int64"), db("id,pk")),
+		*NewStructFieldWithTag("Value", NewType("string"), db("value")),
+	})
+	code := []Code{user, item, row}
+	users, err := NewRepository(user, TableRepositoryOption("app_users"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	items, err := NewRepository(item, PlaceholderRepositoryOption(DollarPlaceholder))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rows, err := NewRepository(row)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := NewFile("repository", rows...).String(); !strings.Contains(got, "var values []*Row") {
+		t.Errorf("NewRepository() = %v, want the List slice to be named values", got)
+	}
+	code = append(append(append(code, users...), items...), rows...)
+	runGeneratedTests(t, "repository", map[string]string{
+		"gen.go":      NewFile("repository", code...).String(),
+		"gen_test.go": repositoryRecordingDriverTest,
+	})
+}
+
+func Test_snakeName(t *testing.T) {
+	tests := map[string]string{
+		"User":        "user",
+		"OrderItem":   "order_item",
+		"HTTPRequest": "http_request",
+		"UserID":      "user_id",
+		"V2Item":      "v2_item",
+	}
+	for name, want := range tests {
+		if got := snakeName(name); got != want {
+			t.Errorf("snakeName(%s) = %v, want %v", name, got, want)
+		}
+	}
+}
+
+const repositoryRecordingDriverTest = `package repository
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+)
+
+// call is a statement executed by the recording driver.
+type call struct {
+	query string
+	args  []driver.Value
+}
+
+// recorder is a driver.Driver that records the statements and returns the queued rows.
+type recorder struct {
+	calls []call
+	rows  [][]driver.Value
+}
+
+func (d *recorder) Open(string) (driver.Conn, error) { return conn{d}, nil }
+
+type conn struct{ d *recorder }
+
+func (c conn) Prepare(query string) (driver.Stmt, error) { return stmt{c.d, query}, nil }
+func (c conn) Close() error                              { return nil }
+func (c conn) Begin() (driver.Tx, error)                 { return nil, errors.New("not supported") }
+
+type stmt struct {
+	d     *recorder
+	query string
+}
+
+func (s stmt) Close() error  { return nil }
+func (s stmt) NumInput() int { return -1 }
+
+func (s stmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.d.calls = append(s.d.calls, call{s.query, args})
+	return driver.RowsAffected(1), nil
+}
+
+func (s stmt) Query(args []driver.Value) (driver.Rows, error) {
+	s.d.calls = append(s.d.calls, call{s.query, args})
+	r := &rows{values: s.d.rows}
+	s.d.rows = nil
+	return r, nil
+}
+
+type rows struct{ values [][]driver.Value }
+
+func (r *rows) Columns() []string {
+	if len(r.values) == 0 {
+		return []string{"id"}
+	}
+	return make([]string, len(r.values[0]))
+}
+func (r *rows) Close() error { return nil }
+func (r *rows) Next(dest []driver.Value) error {
+	if len(r.values) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.values[0])
+	r.values = r.values[1:]
+	return nil
+}
+
+// lastInsert returns the LastInsertId of the exec results.
+type lastInsert struct{ *recorder }
+
+func (d lastInsert) Open(string) (driver.Conn, error) { return lastInsertConn{conn{d.recorder}}, nil }
+
+type lastInsertConn struct{ conn }
+
+func (c lastInsertConn) Prepare(query string) (driver.Stmt, error) {
+	return lastInsertStmt{stmt{c.d, query}}, nil
+}
+
+type lastInsertStmt struct{ stmt }
+
+func (s lastInsertStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.d.calls = append(s.d.calls, call{s.query, args})
+	return result{}, nil
+}
+
+type result struct{}
+
+func (result) LastInsertId() (int64, error) { return 42, nil }
+func (result) RowsAffected() (int64, error) { return 1, nil }
+
+func open(t *testing.T, name string, d driver.Driver) *sql.DB {
+	sql.Register(name, d)
+	db, err := sql.Open(name, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func TestUserRepository(t *testing.T) {
+	ctx := context.Background()
+	d := &recorder{}
+	repo := NewSQLUserRepository(open(t, "users", lastInsert{d}))
+
+	age := 30
+	u := &User{Name: "john", Age: &age}
+	if err := repo.Create(ctx, u); err != nil {
+		t.Fatal(err)
+	}
+	if u.ID != 42 {
+		t.Fatalf("Create() did not set the id, got %d", u.ID)
+	}
+	u.Email = sql.NullString{String: "john@example.com", Valid: true}
+	if err := repo.Update(ctx, u); err != nil {
+		t.Fatal(err)
+	}
+	d.rows = [][]driver.Value{{int64(42), "john", "john@example.com", int64(30)}}
+	got, err := repo.Get(ctx, 42)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, u) {
+		t.Fatalf("Get() = %+v, want %+v", got, u)
+	}
+	if _, err := repo.Get(ctx, 1); !errors.Is(err, sql.ErrNoRows) {
+		t.Fatalf("Get() of a missing user error = %v, want sql.ErrNoRows", err)
+	}
+	d.rows = [][]driver.Value{{int64(1), "a", nil, nil}, {int64(2), "b", "b@example.com", int64(20)}}
+	list, err := repo.List(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 2 || list[0].Name != "a" || list[0].Email.Valid || list[0].Age != nil || list[1].Email.String != "b@example.com" || *list[1].Age != 20 {
+		t.Fatalf("List() = %+v", list)
+	}
+	if err := repo.Delete(ctx, 42); err != nil {
+		t.Fatal(err)
+	}
+	want := []call{
+		{"INSERT INTO app_users (name, email, age) VALUES (?, ?, ?)", []driver.Value{"john", nil, int64(30)}},
+		{"UPDATE app_users SET name = ?, email = ?, age = ? WHERE id = ?", []driver.Value{"john", "john@example.com", int64(30), int64(42)}},
+		{"SELECT id, name, email, age FROM app_users WHERE id = ?", []driver.Value{int64(42)}},
+		{"SELECT id, name, email, age FROM app_users WHERE id = ?", []driver.Value{int64(1)}},
+		{"SELECT id, name, email, age FROM app_users", []driver.Value{}},
+		{"DELETE FROM app_users WHERE id = ?", []driver.Value{int64(42)}},
+	}
+	if !reflect.DeepEqual(d.calls, want) {
+		t.Fatalf("calls = %#v, want %#v", d.calls, want)
+	}
+}
+
+func TestItemRepository(t *testing.T) {
+	ctx := context.Background()
+	d := &recorder{}
+	repo := NewSQLItemRepository(open(t, "items", d))
+
+	d.rows = [][]driver.Value{{int64(7)}}
+	it := &Item{Title: "book"}
+	if err := repo.Create(ctx, it); err != nil {
+		t.Fatal(err)
+	}
+	if it.ID != 7 {
+		t.Fatalf("Create() did not set the id, got %d", it.ID)
+	}
+	if err := repo.Update(ctx, it); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.Delete(ctx, 7); err != nil {
+		t.Fatal(err)
+	}
+	want := []call{
+		{"INSERT INTO items (title) VALUES ($1) RETURNING id", []driver.Value{"book"}},
+		{"UPDATE items SET title = $1 WHERE id = $2", []driver.Value{"book", int64(7)}},
+		{"DELETE FROM items WHERE id = $1", []driver.Value{int64(7)}},
+	}
+	if !reflect.DeepEqual(d.calls, want) {
+		t.Fatalf("calls = %#v, want %#v", d.calls, want)
+	}
+}
+`
@@ -0,0 +1,121 @@
+package code
+
+import (
+	"fmt"
+
+	"github.com/dave/jennifer/jen"
+)
+
+// SQLOptions is used when you call AddSQL, it is a handy way to allow multiple configurations
+// for the generated row methods.
+type SQLOptions func(o *sqlOptions)
+
+type sqlOptions struct {
+	tagKey string
+}
+
+// TagSQLOption sets the tag key of the column names, the default key is db.
+func TagSQLOption(key string) SQLOptions {
+	return func(o *sqlOptions) {
+		o.tagKey = key
+	}
+}
+
+// sqlColumn is a structure field mapped to a database column.
+type sqlColumn struct {
+	name    string
+	field   string
+	tp      Type
+	options []string
+}
+
+// hasOption tells if the column tag has the option (e.x pk).
+func (c sqlColumn) hasOption(option string) bool {
+	for _, o := range c.options {
+		if o == option {
+			return true
+		}
+	}
+	return false
+}
+
+// AddSQL adds the Columns() []string, ScanRow(scanner interface{ Scan(...any) error }) error
+// and Values() []any methods to the structure and returns the added methods.
+//
+// The columns are set in the db tags of the fields (e.x `db:"email"`), fields without a db tag
+// or with `db:"-"` are not mapped. All the methods use the order of the fields.
+// ScanRow scans a row (e.x *sql.Row or *sql.Rows) into the fields and Values returns the field values (e.x for inserts).
+// The fields are passed as they are, so sql.Null* and other sql.Scanner or driver.Valuer types are supported and
+// pointer fields are nil for NULL columns (database/sql allocates the pointers while scanning).
+// An error is returned if a column is mapped twice or if a field type can not be scanned (maps, functions and anonymous structures).
+func AddSQL(st *Struct, options ...SQLOptions) ([]*Function, error) {
+	opts := &sqlOptions{
+		tagKey: "db",
+	}
+	for _, o := range options {
+		o(opts)
+	}
+	columns, err := sqlColumns(st, opts.tagKey)
+	if err != nil {
+		return nil, err
+	}
+	recv := receiverName(st.Name)
+	var names, dest, values []jen.Code
+	for _, c := range columns {
+		names = append(names, jen.Lit(c.name))
+		dest = append(dest, jen.Op("&").Id(recv).Dot(c.field))
+		values = append(values, jen.Id(recv).Dot(c.field))
+	}
+	scanner := NewRawType(jen.Interface(jen.Id("Scan").Params(jen.Op("...").Id("any")).Error()))
+	methods := []*Function{
+		NewFunction(
+			"Columns",
+			ResultsFunctionOption(*NewParameter("", NewType("", ArrayTypeOption(NewType("string"))))),
+			BodyFunctionOption(jen.Return(jen.Index().String().Values(names...))),
+			DocsFunctionOption(Comment("Columns returns the database columns of the "+st.Name+" fields.")),
+		),
+		NewFunction(
+			"ScanRow",
+			ParamsFunctionOption(*NewParameter("scanner", scanner)),
+			ResultsFunctionOption(*NewParameter("", NewType("error"))),
+			BodyFunctionOption(jen.Return(jen.Id("scanner").Dot("Scan").Call(dest...))),
+			DocsFunctionOption(
+				Comment("ScanRow scans the columns of a row (e.x *sql.Row or *sql.Rows) into the "+st.Name+" fields,"),
+				"the row columns must be in the order of Columns.",
+			),
+		),
+		NewFunction(
+			"Values",
+			ResultsFunctionOption(*NewParameter("", NewType("", ArrayTypeOption(NewType("any"))))),
+			BodyFunctionOption(jen.Return(jen.Index().Id("any").Values(values...))),
+			DocsFunctionOption(Comment("Values returns the values of the "+st.Name+" fields in the order of Columns (e.x for inserts).")),
+		),
+	}
+	for _, m := range methods {
+		st.AddMethod(m, ReceiverNameMethodOption(recv))
+	}
+	return methods, nil
+}
+
+// sqlColumns returns the mapped columns of the structure fields,
+// the first option of the tag is the column name and the others are the column options.
+func sqlColumns(st *Struct, tagKey string) ([]sqlColumn, error) {
+	var columns []sqlColumn
+	seen := map[string]string{}
+	for _, f := range st.Fields {
+		tag := tagOptions(f, tagKey)
+		if len(tag) == 0 || tag[0] == "" || tag[0] == "-" {
+			continue
+		}
+		field := fieldName(f)
+		if other, ok := seen[tag[0]]; ok {
+			return nil, fmt.Errorf("the column %s is mapped by the fields %s and %s", tag[0], other, field)
+		}
+		if tp := f.Type; tp.MapType != nil || tp.Function != nil || tp.Struct != nil {
+			return nil, fmt.Errorf("the field %s: the type %s can not be scanned", field, tp.String())
+		}
+		seen[tag[0]] = field
+		columns = append(columns, sqlColumn{name: tag[0], field: field, tp: f.Type, options: tag[1:]})
+	}
+	return columns, nil
+}
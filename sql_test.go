@@ -0,0 +1,211 @@
+package code
+
+import (
+	"testing"
+)
+
+func TestAddSQL(t *testing.T) {
+	db := func(v string) *FieldTags { return NewFieldTags("db", v) }
+	tests := []struct {
+		name    string
+		fields  []StructField
+		options []SQLOptions
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "Should add the row methods",
+			fields: []StructField{
+				*NewStructFieldWithTag("ID", NewType("int64"), db("id")),
+				*NewStructFieldWithTag("Email", NewType("NullString", ImportTypeOption(*NewImport("", "database/sql"))), db("email")),
+				*NewStructFieldWithTag("Age", NewType("int", PointerTypeOption()), db("age")),
+				*NewStructFieldWithTag("Skip", NewType("string"), db("-")),
+				*NewStructField("Other", NewType("string")),
+			},
+			want: `package test
+
+import "database/sql"
+
+type User struct {
+	ID    int64          ` + "`db:\"id\"`" + `
+	Email sql.NullString ` + "`db:\"email\"`" + `
+	Age   *int           ` + "`db:\"age\"`" + `
+	Skip  string         ` + "`db:\"-\"`" + `
+	Other string
+}
+
+// Columns returns the database columns of the User fields.
+func (u *User) Columns() []string {
+	return []string{"id", "email", "age"}
+}
+
+// ScanRow scans the columns of a row (e.x *sql.Row or *sql.Rows) into the User fields,
+// the row columns must be in the order of Columns.
+func (u *User) ScanRow(scanner interface {
+	Scan(...any) error
+}) error {
+	return scanner.Scan(&u.ID, &u.Email, &u.Age)
+}
+
+// Values returns the values of the User fields in the order of Columns (e.x for inserts).
+func (u *User) Values() []any {
+	return []any{u.ID, u.Email, u.Age}
+}
+`,
+		},
+		{
+			name: "Should use the tag key of the option",
+			fields: []StructField{
+				*NewStructFieldWithTag("ID", NewType("int64"), NewFieldTags("sql", "id")),
+			},
+			options: []SQLOptions{TagSQLOption("sql")},
+			want: `package test
+
+type User struct {
+	ID int64 ` + "`sql:\"id\"`" + `
+}
+
+// Columns returns the database columns of the User fields.
+func (u *User) Columns() []string {
+	return []string{"id"}
+}
+
+// ScanRow scans the columns of a row (e.x *sql.Row or *sql.Rows) into the User fields,
+// the row columns must be in the order of Columns.
+func (u *User) ScanRow(scanner interface {
+	Scan(...any) error
+}) error {
+	return scanner.Scan(&u.ID)
+}
+
+// Values returns the values of the User fields in the order of Columns (e.x for inserts).
+func (u *User) Values() []any {
+	return []any{u.ID}
+}
+`,
+		},
+		{
+			name: "Should return an error for duplicated columns",
+			fields: []StructField{
+				*NewStructFieldWithTag("ID", NewType("int64"), db("id")),
+				*NewStructFieldWithTag("Key", NewType("int64"), db("id")),
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should return an error for maps",
+			fields: []StructField{
+				*NewStructFieldWithTag("Labels", NewType("", MapTypeOption(NewType("string"), NewType("string"))), db("labels")),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := NewStructWithFields("User", tt.fields)
+			_, err := AddSQL(st, tt.options...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("AddSQL() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got := NewFile("test", st).String(); got != tt.want {
+				t.Errorf("AddSQL() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAddSQL_FakeScan(t *testing.T) {
+	db := func(v string) *FieldTags { return NewFieldTags("db", v) }
+	user := NewStructWithFields("User", []StructField{
+		*NewStructFieldWithTag("ID", NewType("int64"), db("id")),
+		*NewStructFieldWithTag("Name", NewType("string"), db("name")),
+		*NewStructFieldWithTag("Email", NewType("NullString", ImportTypeOption(*NewImport("", "database/sql"))), db("email")),
+		*NewStructFieldWithTag("Age", NewType("int", PointerTypeOption()), db("age")),
+		*NewStructField("Other", NewType("string")),
+	})
+	if _, err := AddSQL(user); err != nil {
+		t.Fatal(err)
+	}
+	runGeneratedTests(t, "fakescan", map[string]string{
+		"gen.go":      NewFile("fakescan", user).String(),
+		"gen_test.go": sqlFakeScanTest,
+	})
+}
+
+const sqlFakeScanTest = `package fakescan
+
+import (
+	"database/sql"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// fakeRow scans its values like database/sql, nil values are NULL.
+type fakeRow []any
+
+func (r fakeRow) Scan(dest ...any) error {
+	if len(dest) != len(r) {
+		return errors.New("wrong number of columns")
+	}
+	for i, d := range dest {
+		if s, ok := d.(sql.Scanner); ok {
+			if err := s.Scan(r[i]); err != nil {
+				return err
+			}
+			continue
+		}
+		v := reflect.ValueOf(d).Elem()
+		switch {
+		case r[i] == nil:
+			v.Set(reflect.Zero(v.Type()))
+		case v.Kind() == reflect.Ptr:
+			p := reflect.New(v.Type().Elem())
+			p.Elem().Set(reflect.ValueOf(r[i]).Convert(v.Type().Elem()))
+			v.Set(p)
+		default:
+			v.Set(reflect.ValueOf(r[i]).Convert(v.Type()))
+		}
+	}
+	return nil
+}
+
+func TestScanRow(t *testing.T) {
+	var u User
+	if got, want := u.Columns(), []string{"id", "name", "email", "age"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("Columns() = %v, want %v", got, want)
+	}
+	if err := u.ScanRow(fakeRow{int64(1), "john", "john@example.com", int64(30)}); err != nil {
+		t.Fatal(err)
+	}
+	if u.ID != 1 || u.Name != "john" || u.Email != (sql.NullString{String: "john@example.com", Valid: true}) || u.Age == nil || *u.Age != 30 {
+		t.Fatalf("ScanRow() = %+v", u)
+	}
+	if err := u.ScanRow(fakeRow{int64(2), "jane", nil, nil}); err != nil {
+		t.Fatal(err)
+	}
+	if u.ID != 2 || u.Name != "jane" || u.Email.Valid || u.Age != nil {
+		t.Fatalf("ScanRow() with NULL columns = %+v", u)
+	}
+	if err := u.ScanRow(fakeRow{int64(3)}); err == nil {
+		t.Fatal("ScanRow() with missing columns should fail")
+	}
+	age := 40
+	u = User{ID: 4, Name: "joe", Email: sql.NullString{String: "joe@example.com", Valid: true}, Age: &age, Other: "x"}
+	values := u.Values()
+	if len(values) != 4 || values[0] != int64(4) || values[1] != "joe" || values[2] != u.Email || values[3] != &age {
+		t.Fatalf("Values() = %v", values)
+	}
+	var scanned User
+	if err := scanned.ScanRow(fakeRow{int64(4), "joe", "joe@example.com", int64(40)}); err != nil {
+		t.Fatal(err)
+	}
+	scanned.Other = "x"
+	if !reflect.DeepEqual(scanned, u) {
+		t.Fatalf("ScanRow() = %+v, want %+v", scanned, u)
+	}
+}
+`
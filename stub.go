@@ -0,0 +1,88 @@
+package code
+
+import (
+	"github.com/dave/jennifer/jen"
+)
+
+// StubOptions is used when you call NewStub, it is a handy way to allow multiple configurations
+// for the generated stub.
+type StubOptions func(o *stubOptions)
+
+type stubOptions struct {
+	panic         bool
+	err           bool
+	methodOptions []MethodOptions
+}
+
+// PanicStubOption makes every stub method panic with "not implemented" instead of returning zero values.
+func PanicStubOption() StubOptions {
+	return func(o *stubOptions) {
+		o.panic = true
+	}
+}
+
+// ErrorStubOption makes the stub methods return errors.New("not implemented") if their last result is an error,
+// the other results are still zero values.
+func ErrorStubOption() StubOptions {
+	return func(o *stubOptions) {
+		o.err = true
+	}
+}
+
+// MethodStubOption configures the receiver of the stub methods (e.x ValueReceiverMethodOption()).
+func MethodStubOption(options ...MethodOptions) StubOptions {
+	return func(o *stubOptions) {
+		o.methodOptions = append(o.methodOptions, options...)
+	}
+}
+
+// NewStub creates a stub implementation of the interface.
+//
+// It returns a structure with the given name that has a method for every interface method and
+// a compile-time assertion that the structure implements the interface (e.x var _ Iface = (*Impl)(nil)).
+// By default the stub methods return the zero values of their results.
+// The stub of a generic interface has the same type parameters (e.x Impl[T any]).
+func NewStub(name string, iface *Interface, options ...StubOptions) []Code {
+	opts := &stubOptions{}
+	for _, o := range options {
+		o(opts)
+	}
+	// the receiver must not be one of the parameter names, the options can still set it.
+	methodOptions := append(
+		[]MethodOptions{ReceiverNameMethodOption(freeName(receiverName(name), unexportedName(name), iface.Methods))},
+		opts.methodOptions...,
+	)
+	st := NewStruct(name, Comment(name+" is a stub implementation of "+iface.Name+"."))
+	st.TypeParams = cloneParams(iface.TypeParams)
+	for _, m := range iface.Methods {
+		fn := NewFunction(
+			m.Name,
+			ParamsFunctionOption(cloneParams(m.Params)...),
+			ResultsFunctionOption(cloneParams(m.Results)...),
+			BodyFunctionOption(opts.body(m.Params, m.Results)...),
+		)
+		st.AddMethod(fn, methodOptions...)
+	}
+	return []Code{st, NewInterfaceAssertion(iface, methodReceiver(name, st.TypeParams, methodOptions).Type)}
+}
+
+func (o *stubOptions) body(params, results []Parameter) []jen.Code {
+	if o.panic {
+		return []jen.Code{jen.Panic(jen.Lit("not implemented"))}
+	}
+	var errorValue *jen.Statement
+	if o.err {
+		errorValue = jen.Qual("errors", "New").Call(jen.Lit("not implemented"))
+	}
+	if len(results) == 0 {
+		return nil
+	}
+	return zeroReturn(params, results, errorValue)
+}
+
+// methodReceiver returns the receiver AddMethod would set for the type name and options.
+func methodReceiver(typeName string, typeParams []Parameter, options []MethodOptions) *Parameter {
+	fn := &Function{}
+	setReceiver(typeName, typeParams, fn, options)
+	return fn.Recv
+}
@@ -0,0 +1,212 @@
+package code
+
+import (
+	"testing"
+)
+
+func TestNewStub(t *testing.T) {
+	iface := NewInterface("Service", []InterfaceMethod{
+		NewInterfaceMethod("Get",
+			ParamsFunctionOption(
+				*NewParameter("ctx", NewType("Context", ImportTypeOption(*NewImport("", "context")))),
+				*NewParameter("id", NewType("string")),
+			),
+			ResultsFunctionOption(*NewParameter("", NewType("User")), *NewParameter("", NewType("error"))),
+		),
+		NewInterfaceMethod("Count",
+			ResultsFunctionOption(*NewParameter("n", NewType("int")), *NewParameter("ok", NewType("bool"))),
+		),
+		NewInterfaceMethod("Close"),
+	})
+	tests := []struct {
+		name     string
+		stubName string
+		iface    *Interface
+		options  []StubOptions
+		want     string
+	}{
+		{
+			name:     "Should create a stub returning zero values",
+			stubName: "Impl",
+			iface:    iface,
+			want: `package test
+
+import "context"
+
+// Impl is a stub implementation of Service.
+type Impl struct{}
+
+func (i *Impl) Get(ctx context.Context, id string) (User, error) {
+	var r0 User
+	return r0, nil
+}
+
+func (i *Impl) Count() (n int, ok bool) {
+	return n, ok
+}
+
+func (i *Impl) Close() {}
+
+var _ Service = (*Impl)(nil)
+`,
+		},
+		{
+			name:     "Should create a stub that panics",
+			stubName: "Impl",
+			iface:    iface,
+			options:  []StubOptions{PanicStubOption()},
+			want: `package test
+
+import "context"
+
+// Impl is a stub implementation of Service.
+type Impl struct{}
+
+func (i *Impl) Get(ctx context.Context, id string) (User, error) {
+	panic("not implemented")
+}
+
+func (i *Impl) Count() (n int, ok bool) {
+	panic("not implemented")
+}
+
+func (i *Impl) Close() {
+	panic("not implemented")
+}
+
+var _ Service = (*Impl)(nil)
+`,
+		},
+		{
+			name:     "Should create a stub returning errors with value receivers",
+			stubName: "Impl",
+			iface:    iface,
+			options: []StubOptions{
+				ErrorStubOption(),
+				MethodStubOption(ValueReceiverMethodOption(), ReceiverNameMethodOption("s")),
+			},
+			want: `package test
+
+import (
+	"context"
+	"errors"
+)
+
+// Impl is a stub implementation of Service.
+type Impl struct{}
+
+func (s Impl) Get(ctx context.Context, id string) (User, error) {
+	var r0 User
+	return r0, errors.New("not implemented")
+}
+
+func (s Impl) Count() (n int, ok bool) {
+	return n, ok
+}
+
+func (s Impl) Close() {}
+
+var _ Service = Impl{}
+`,
+		},
+		{
+			name:     "Should not use the parameter names as the receiver",
+			stubName: "Store",
+			iface: NewInterface("KV", []InterfaceMethod{
+				NewInterfaceMethod("Put", ParamsFunctionOption(*NewParameter("s", NewType("string")))),
+			}),
+			want: `package test
+
+// Store is a stub implementation of KV.
+type Store struct{}
+
+func (store *Store) Put(s string) {}
+
+var _ KV = (*Store)(nil)
+`,
+		},
+		{
+			name:     "Should use the type parameters of a generic interface",
+			stubName: "Store",
+			iface: &Interface{
+				Name: "KV",
+				Methods: []InterfaceMethod{
+					NewInterfaceMethod("Get", ResultsFunctionOption(*NewParameter("", NewType("T")))),
+				},
+				TypeParams: []Parameter{*NewParameter("T", NewType("any"))},
+			},
+			want: `package test
+
+// Store is a stub implementation of KV.
+type Store[T any] struct{}
+
+func (s *Store[T]) Get() T {
+	var r0 T
+	return r0
+}
+
+func _[T any]() {
+	var _ KV[T] = (*Store[T])(nil)
+}
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code := NewStub(tt.stubName, tt.iface, tt.options...)
+			if got := NewFile("test", code...).String(); got != tt.want {
+				t.Errorf("NewStub() = %v, want %v", got, tt.want)
+			}
+			if err := Implements(code[0].(*Struct), tt.iface); err != nil {
+				t.Errorf("NewStub() does not implement the interface: %v", err)
+			}
+		})
+	}
+}
+
+// TestNewStub_Compile compiles the stubs of an interface whose parameters use the generated names.
+func TestNewStub_Compile(t *testing.T) {
+	iface := NewInterface("Service", []InterfaceMethod{
+		NewInterfaceMethod("Get",
+			ParamsFunctionOption(
+				*NewParameter("ctx", NewType("Context", ImportTypeOption(*NewImport("", "context")))),
+				*NewParameter("r0", NewType("string")),
+			),
+			ResultsFunctionOption(*NewParameter("", NewType("User")), *NewParameter("", NewType("error"))),
+		),
+		NewInterfaceMethod("Log", ParamsFunctionOption(*NewParameter("args", NewType("interface{}", VariadicTypeOption())))),
+		NewInterfaceMethod("Count", ResultsFunctionOption(*NewParameter("n", NewType("int")), *NewParameter("ok", NewType("bool")))),
+	})
+	files := map[string]string{
+		"service.go":  "package stub\n\ntype User struct{}\n",
+		"gen_test.go": stubCompileTest,
+	}
+	files["iface.go"] = NewFile("stub", iface).String()
+	files["zero.go"] = NewFile("stub", NewStub("Zero", iface)...).String()
+	files["errs.go"] = NewFile("stub", NewStub("Errs", iface, ErrorStubOption(), MethodStubOption(ValueReceiverMethodOption()))...).String()
+	files["panics.go"] = NewFile("stub", NewStub("Panics", iface, PanicStubOption())...).String()
+	runGeneratedTests(t, "stub", files)
+}
+
+const stubCompileTest = `package stub
+
+import (
+	"context"
+	"testing"
+)
+
+func TestStubs(t *testing.T) {
+	if _, err := (&Zero{}).Get(context.Background(), "a"); err != nil {
+		t.Errorf("Zero.Get() error = %v", err)
+	}
+	if _, err := (Errs{}).Get(context.Background(), "a"); err == nil {
+		t.Error("Errs.Get() expected an error")
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("Panics.Count() expected a panic")
+		}
+	}()
+	(&Panics{}).Count()
+}
+`
@@ -0,0 +1,188 @@
+package code
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dave/jennifer/jen"
+)
+
+// SumTypeOptions is used when you call NewSumType, it is a handy way to allow multiple configurations
+// for the generated sum type.
+type SumTypeOptions func(o *sumTypeOptions)
+
+type sumTypeOptions struct {
+	discriminator string
+}
+
+// DiscriminatorSumTypeOption sets the JSON field that holds the variant, the default field is type.
+func DiscriminatorSumTypeOption(field string) SumTypeOptions {
+	return func(o *sumTypeOptions) {
+		o.discriminator = field
+	}
+}
+
+// NewSumType creates the sealed interface of the sum type with the given variants.
+//
+// The interface only has the unexported is{Name}() marker method, the marker is added to the variants
+// with a pointer receiver so the variants of the sum type are the pointers to the variant structures (e.x *Circle).
+// Together with the interface it creates:
+//   - {Name}Visitor: an interface with a Visit{Variant}(*Variant) error method for every variant
+//   - Match{Name}({name} {Name}, visitor {Name}Visitor) error: calls the visitor method of the variant
+//   - Marshal{Name}JSON({name} {Name}) ([]byte, error): encodes the variant with the discriminator field
+//   - Unmarshal{Name}JSON(data []byte) ({Name}, error): decodes the variant set in the discriminator field
+//
+// The JSON values of the discriminator are the snake case names of the variants (e.x OrderItem is order_item),
+// the variants are encoded with encoding/json so they must be encoded as JSON objects.
+// An error is returned if there are no variants, if two variants have the same name or if a variant
+// has a field with the JSON name of the discriminator in any case (e.x Type for type).
+func NewSumType(name string, variants []*Struct, options ...SumTypeOptions) ([]Code, error) {
+	opts := &sumTypeOptions{
+		discriminator: "type",
+	}
+	for _, o := range options {
+		o(opts)
+	}
+	if len(variants) == 0 {
+		return nil, fmt.Errorf("the sum type %s has no variants", name)
+	}
+	seen := map[string]bool{}
+	for _, v := range variants {
+		if seen[v.Name] {
+			return nil, fmt.Errorf("the sum type %s has the variant %s more than once", name, v.Name)
+		}
+		seen[v.Name] = true
+		for _, f := range v.Fields {
+			// encoding/json matches the object keys case insensitively.
+			if strings.EqualFold(jsonFieldName(f), opts.discriminator) {
+				return nil, fmt.Errorf("the field %s of the variant %s has the JSON name of the discriminator %s", fieldName(f), v.Name, opts.discriminator)
+			}
+		}
+	}
+	marker := "is" + exportedName(name)
+	value := paramName(name)
+	switch value {
+	case "v", "data", "visitor", "variant", "buf", "probe", "err":
+		// the names are used by the generated code.
+		value = "value"
+	}
+	visitor := name + "Visitor"
+	iface := NewInterface(
+		name,
+		[]InterfaceMethod{NewInterfaceMethod(marker)},
+		Comment(fmt.Sprintf("%s is one of %s, the variants are the pointers to the structures.", name, variantList(variants))),
+	)
+	var visits []InterfaceMethod
+	var matches, marshals, unmarshals []jen.Code
+	for _, v := range variants {
+		v.AddMethod(
+			NewFunction(marker, DocsFunctionOption(Comment(marker+" marks "+v.Name+" as a variant of "+name+"."))),
+			ReceiverNameMethodOption(""),
+		)
+		ptr := jen.Op("*").Id(v.Name)
+		visits = append(visits, NewInterfaceMethod(
+			"Visit"+v.Name,
+			ParamsFunctionOption(*NewParameter(paramName(v.Name), NewType(v.Name, PointerTypeOption()))),
+			ResultsFunctionOption(*NewParameter("", NewType("error"))),
+		))
+		matches = append(matches, jen.Case(ptr.Clone()).Block(jen.Return(jen.Id("visitor").Dot("Visit"+v.Name).Call(jen.Id("v")))))
+		marshals = append(marshals, jen.Case(ptr.Clone()).Block(jen.Id("variant").Op("=").Lit(snakeName(v.Name))))
+		unmarshals = append(unmarshals, jen.Case(jen.Lit(snakeName(v.Name))).Block(jen.Id(value).Op("=").Op("&").Id(v.Name).Values()))
+	}
+	visitorIface := NewInterface(visitor, visits, Comment(visitor+" has a method for every variant of "+name+", it is used with Match"+exportedName(name)+"."))
+
+	unknown := func(format string, args ...jen.Code) jen.Code {
+		return jen.Qual("fmt", "Errorf").Call(append([]jen.Code{jen.Lit(format)}, args...)...)
+	}
+	match := NewFunction(
+		"Match"+exportedName(name),
+		ParamsFunctionOption(
+			*NewParameter(value, NewType(name)),
+			*NewParameter("visitor", NewType(visitor)),
+		),
+		ResultsFunctionOption(*NewParameter("", NewType("error"))),
+		BodyFunctionOption(
+			jen.Switch(jen.Id("v").Op(":=").Id(value).Assert(jen.Type())).Block(matches...),
+			jen.Return(unknown("unknown "+name+" variant %T", jen.Id(value))),
+		),
+		DocsFunctionOption(
+			Comment("Match"+exportedName(name)+" calls the method of the visitor for the variant of the "+value+","),
+			Comment("it returns an error if the "+value+" is nil."),
+		),
+	)
+	marshals = append(marshals, jen.Default().Block(jen.Return(jen.Nil(), unknown("unknown "+name+" variant %T", jen.Id(value)))))
+	marshal := NewFunction(
+		"Marshal"+exportedName(name)+"JSON",
+		ParamsFunctionOption(*NewParameter(value, NewType(name))),
+		ResultsFunctionOption(*NewParameter("", NewType("", ArrayTypeOption(NewType("byte")))), *NewParameter("", NewType("error"))),
+		BodyFunctionOption(
+			jen.Var().Id("variant").String(),
+			jen.Switch(jen.Id(value).Assert(jen.Type())).Block(marshals...),
+			jen.List(jen.Id("data"), jen.Err()).Op(":=").Qual("encoding/json", "Marshal").Call(jen.Id(value)),
+			jen.If(jen.Err().Op("!=").Nil()).Block(jen.Return(jen.Nil(), jen.Err())),
+			jen.If(jen.Len(jen.Id("data")).Op("<").Lit(2).Op("||").Id("data").Index(jen.Lit(0)).Op("!=").LitRune('{')).Block(
+				jen.Return(jen.Nil(), unknown("the "+name+" variant %T is not encoded as a JSON object", jen.Id(value))),
+			),
+			jen.Id("buf").Op(":=").Append(
+				jen.Index().Byte().Call(jen.Lit(fmt.Sprintf("{%q:", opts.discriminator))),
+				jen.Qual("strconv", "Quote").Call(jen.Id("variant")).Op("..."),
+			),
+			jen.If(jen.Len(jen.Id("data")).Op(">").Lit(2)).Block(
+				jen.Id("buf").Op("=").Append(jen.Id("buf"), jen.LitRune(',')),
+			),
+			jen.Return(jen.Append(jen.Id("buf"), jen.Id("data").Index(jen.Lit(1), jen.Empty()).Op("...")), jen.Nil()),
+		),
+		DocsFunctionOption(
+			Comment("Marshal"+exportedName(name)+"JSON returns the JSON encoding of the "+value+" with its variant in the "+opts.discriminator+" field"),
+			Comment(fmt.Sprintf("(e.x {%q:%q,...}).", opts.discriminator, snakeName(variants[0].Name))),
+		),
+	)
+	unmarshals = append(unmarshals, jen.Default().Block(
+		jen.Return(jen.Nil(), unknown("unknown "+name+" variant %q", jen.Id("probe").Dot("Variant"))),
+	))
+	unmarshal := NewFunction(
+		"Unmarshal"+exportedName(name)+"JSON",
+		ParamsFunctionOption(*NewParameter("data", NewType("", ArrayTypeOption(NewType("byte"))))),
+		ResultsFunctionOption(*NewParameter("", NewType(name)), *NewParameter("", NewType("error"))),
+		BodyFunctionOption(
+			jen.Var().Id("probe").Struct(jen.Id("Variant").String().Tag(map[string]string{"json": opts.discriminator})),
+			jen.If(
+				jen.Err().Op(":=").Qual("encoding/json", "Unmarshal").Call(jen.Id("data"), jen.Op("&").Id("probe")),
+				jen.Err().Op("!=").Nil(),
+			).Block(jen.Return(jen.Nil(), jen.Err())),
+			jen.Var().Id(value).Id(name),
+			jen.Switch(jen.Id("probe").Dot("Variant")).Block(unmarshals...),
+			jen.If(
+				jen.Err().Op(":=").Qual("encoding/json", "Unmarshal").Call(jen.Id("data"), jen.Id(value)),
+				jen.Err().Op("!=").Nil(),
+			).Block(jen.Return(jen.Nil(), jen.Err())),
+			jen.Return(jen.Id(value), jen.Nil()),
+		),
+		DocsFunctionOption(
+			Comment("Unmarshal"+exportedName(name)+"JSON decodes the variant set in the "+opts.discriminator+" field of the JSON object,"),
+			"it returns an error if the variant is unknown.",
+		),
+	)
+	return []Code{iface, visitorIface, match, marshal, unmarshal}, nil
+}
+
+// jsonFieldName returns the name of the field in encoding/json objects.
+func jsonFieldName(f StructField) string {
+	if tag := tagOptions(f, "json"); len(tag) > 0 && tag[0] != "" {
+		return tag[0]
+	}
+	return fieldName(f)
+}
+
+// variantList returns the names of the variants (e.x Circle, Square and Triangle).
+func variantList(variants []*Struct) string {
+	s := variants[0].Name
+	for i, v := range variants[1:] {
+		if i == len(variants)-2 {
+			s += " and " + v.Name
+		} else {
+			s += ", " + v.Name
+		}
+	}
+	return s
+}
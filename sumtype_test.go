@@ -0,0 +1,249 @@
+package code
+
+import (
+	"testing"
+)
+
+func TestNewSumType(t *testing.T) {
+	json := func(v string) *FieldTags { return NewFieldTags("json", v) }
+	tests := []struct {
+		name     string
+		variants func() []*Struct
+		options  []SumTypeOptions
+		want     string
+		wantErr  bool
+	}{
+		{
+			name: "Should create the sum type",
+			variants: func() []*Struct {
+				return []*Struct{
+					NewStructWithFields("Circle", []StructField{*NewStructFieldWithTag("Radius", NewType("float64"), json("radius"))}),
+					NewStruct("Empty"),
+				}
+			},
+			options: []SumTypeOptions{DiscriminatorSumTypeOption("kind")},
+			want: `package test
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+type Circle struct {
+	Radius float64 ` + "`json:\"radius\"`" + `
+}
+
+// isShape marks Circle as a variant of Shape.
+func (*Circle) isShape() {}
+
+type Empty struct{}
+
+// isShape marks Empty as a variant of Shape.
+func (*Empty) isShape() {}
+
+// Shape is one of Circle and Empty, the variants are the pointers to the structures.
+type Shape interface {
+	isShape()
+}
+
+// ShapeVisitor has a method for every variant of Shape, it is used with MatchShape.
+type ShapeVisitor interface {
+	VisitCircle(circle *Circle) error
+	VisitEmpty(empty *Empty) error
+}
+
+// MatchShape calls the method of the visitor for the variant of the shape,
+// it returns an error if the shape is nil.
+func MatchShape(shape Shape, visitor ShapeVisitor) error {
+	switch v := shape.(type) {
+	case *Circle:
+		return visitor.VisitCircle(v)
+	case *Empty:
+		return visitor.VisitEmpty(v)
+	}
+	return fmt.Errorf("unknown Shape variant %T", shape)
+}
+
+// MarshalShapeJSON returns the JSON encoding of the shape with its variant in the kind field
+// (e.x {"kind":"circle",...}).
+func MarshalShapeJSON(shape Shape) ([]byte, error) {
+	var variant string
+	switch shape.(type) {
+	case *Circle:
+		variant = "circle"
+	case *Empty:
+		variant = "empty"
+	default:
+		return nil, fmt.Errorf("unknown Shape variant %T", shape)
+	}
+	data, err := json.Marshal(shape)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < 2 || data[0] != '{' {
+		return nil, fmt.Errorf("the Shape variant %T is not encoded as a JSON object", shape)
+	}
+	buf := append([]byte("{\"kind\":"), strconv.Quote(variant)...)
+	if len(data) > 2 {
+		buf = append(buf, ',')
+	}
+	return append(buf, data[1:]...), nil
+}
+
+// UnmarshalShapeJSON decodes the variant set in the kind field of the JSON object,
+// it returns an error if the variant is unknown.
+func UnmarshalShapeJSON(data []byte) (Shape, error) {
+	var probe struct {
+		Variant string ` + "`json:\"kind\"`" + `
+	}
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return nil, err
+	}
+	var shape Shape
+	switch probe.Variant {
+	case "circle":
+		shape = &Circle{}
+	case "empty":
+		shape = &Empty{}
+	default:
+		return nil, fmt.Errorf("unknown Shape variant %q", probe.Variant)
+	}
+	if err := json.Unmarshal(data, shape); err != nil {
+		return nil, err
+	}
+	return shape, nil
+}
+`,
+		},
+		{
+			name:     "Should return an error without variants",
+			variants: func() []*Struct { return nil },
+			wantErr:  true,
+		},
+		{
+			name: "Should return an error for duplicated variants",
+			variants: func() []*Struct {
+				return []*Struct{NewStruct("Circle"), NewStruct("Circle")}
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should return an error if a field has the name of the discriminator",
+			variants: func() []*Struct {
+				return []*Struct{NewStructWithFields("Circle", []StructField{*NewStructFieldWithTag("Kind", NewType("string"), json("type"))})}
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should return an error if a field has the name of the discriminator in another case",
+			variants: func() []*Struct {
+				return []*Struct{NewStructWithFields("Circle", []StructField{*NewStructField("Type", NewType("string"))})}
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			variants := tt.variants()
+			got, err := NewSumType("Shape", variants, tt.options...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewSumType() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			var code []Code
+			for _, v := range variants {
+				code = append(code, v)
+			}
+			if s := NewFile("test", append(code, got...)...).String(); s != tt.want {
+				t.Errorf("NewSumType() = %v, want %v", s, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewSumType_RoundTrip(t *testing.T) {
+	json := func(v string) *FieldTags { return NewFieldTags("json", v) }
+	circle := NewStructWithFields("Circle", []StructField{*NewStructFieldWithTag("Radius", NewType("float64"), json("radius"))})
+	triangle := NewStructWithFields("RightTriangle", []StructField{
+		*NewStructFieldWithTag("A", NewType("float64"), json("a")),
+		*NewStructField("B", NewType("float64")),
+	})
+	empty := NewStruct("Empty")
+	code, err := NewSumType("Shape", []*Struct{circle, triangle, empty})
+	if err != nil {
+		t.Fatal(err)
+	}
+	runGeneratedTests(t, "sumtype", map[string]string{
+		"gen.go":      NewFile("sumtype", append([]Code{circle, triangle, empty}, code...)...).String(),
+		"gen_test.go": sumTypeRoundTripTest,
+	})
+}
+
+const sumTypeRoundTripTest = `package sumtype
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type names []string
+
+func (n *names) VisitCircle(*Circle) error               { *n = append(*n, "circle"); return nil }
+func (n *names) VisitRightTriangle(*RightTriangle) error { *n = append(*n, "triangle"); return nil }
+func (n *names) VisitEmpty(*Empty) error                 { return errors.New("empty") }
+
+func TestRoundTrip(t *testing.T) {
+	tests := []struct {
+		shape Shape
+		json  string
+	}{
+		{&Circle{Radius: 1.5}, ` + "`" + `{"type":"circle","radius":1.5}` + "`" + `},
+		{&RightTriangle{A: 3, B: 4}, ` + "`" + `{"type":"right_triangle","a":3,"B":4}` + "`" + `},
+		{&Empty{}, ` + "`" + `{"type":"empty"}` + "`" + `},
+	}
+	for _, tt := range tests {
+		data, err := MarshalShapeJSON(tt.shape)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != tt.json {
+			t.Errorf("MarshalShapeJSON() = %s, want %s", data, tt.json)
+		}
+		got, err := UnmarshalShapeJSON(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, tt.shape) {
+			t.Errorf("UnmarshalShapeJSON() = %#v, want %#v", got, tt.shape)
+		}
+	}
+	if _, err := UnmarshalShapeJSON([]byte(` + "`" + `{"type":"square"}` + "`" + `)); err == nil {
+		t.Error("UnmarshalShapeJSON() of an unknown variant should fail")
+	}
+	if _, err := MarshalShapeJSON(nil); err == nil {
+		t.Error("MarshalShapeJSON() of nil should fail")
+	}
+}
+
+func TestMatch(t *testing.T) {
+	var n names
+	for _, s := range []Shape{&Circle{}, &RightTriangle{}} {
+		if err := MatchShape(s, &n); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if !reflect.DeepEqual(n, names{"circle", "triangle"}) {
+		t.Errorf("MatchShape() visited %v", n)
+	}
+	if err := MatchShape(&Empty{}, &n); err == nil || err.Error() != "empty" {
+		t.Errorf("MatchShape() error = %v, want the visitor error", err)
+	}
+	if err := MatchShape(nil, &n); err == nil {
+		t.Error("MatchShape() of nil should fail")
+	}
+}
+`
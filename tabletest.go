@@ -0,0 +1,270 @@
+package code
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/dave/jennifer/jen"
+)
+
+// TestOptions is used when you call NewTestFile, NewTableTest, NewBenchmark or NewFuzzTest, it is a handy way
+// to allow multiple configurations for the generated tests.
+type TestOptions func(o *testOptions)
+
+type testOptions struct {
+	importPath string
+	benchmark  bool
+	fuzz       bool
+}
+
+// ImportTestOption sets the import path of the tested package, it is needed if the tests are in an external
+// test package (e.x package foo_test), the function and the types of the package are then used with the import.
+func ImportTestOption(path string) TestOptions {
+	return func(o *testOptions) {
+		o.importPath = path
+	}
+}
+
+// BenchmarkTestOption also adds the benchmark of the function to the test file, see NewBenchmark.
+func BenchmarkTestOption() TestOptions {
+	return func(o *testOptions) {
+		o.benchmark = true
+	}
+}
+
+// FuzzTestOption also adds the fuzz test of the function to the test file, see NewFuzzTest.
+func FuzzTestOption() TestOptions {
+	return func(o *testOptions) {
+		o.fuzz = true
+	}
+}
+
+// NewTestFile creates a test file (e.x foo_test.go) with the table driven test of the function, see NewTableTest.
+// The benchmark and the fuzz test of the function are added with BenchmarkTestOption and FuzzTestOption.
+//
+// If the package name is an external test package (e.x foo_test) the import path of the tested package
+// must be set with ImportTestOption.
+func NewTestFile(packageName string, fn *Function, options ...TestOptions) (*File, error) {
+	opts := &testOptions{}
+	for _, o := range options {
+		o(opts)
+	}
+	if strings.HasSuffix(packageName, "_test") && opts.importPath == "" {
+		return nil, fmt.Errorf("the import path of the tested package is needed for the external test package %s", packageName)
+	}
+	test, err := NewTableTest(fn, options...)
+	if err != nil {
+		return nil, err
+	}
+	code := []Code{test}
+	if opts.benchmark {
+		benchmark, err := NewBenchmark(fn, options...)
+		if err != nil {
+			return nil, err
+		}
+		code = append(code, benchmark)
+	}
+	if opts.fuzz {
+		fuzz, err := NewFuzzTest(fn, options...)
+		if err != nil {
+			return nil, err
+		}
+		code = append(code, fuzz)
+	}
+	return NewFile(packageName, code...), nil
+}
+
+// NewTableTest creates the table driven test of the function or method like gotests does
+// (e.x TestSum for Sum, Test_sum for sum and TestUser_Name for the Name method of User).
+//
+// The test has a table of test cases with the name of the case, the receiver of methods, the args of the function,
+// the wanted results (want, want1...) and wantErr if the last result is an error.
+// Every case runs with t.Run and the results are compared with reflect.DeepEqual, the table is left empty.
+// An error is returned if ImportTestOption is set and the function or one of the used package types are unexported.
+func NewTableTest(fn *Function, options ...TestOptions) (*Function, error) {
+	opts := &testOptions{}
+	for _, o := range options {
+		o(opts)
+	}
+	recv, err := testReceiver(fn, opts)
+	if err != nil {
+		return nil, err
+	}
+	label := fn.Name
+	if fn.Recv != nil {
+		label = fn.Recv.Type.Qualifier + "." + fn.Name
+	}
+
+	params := namedParams(fn.Params, "arg")
+	results := fn.Results
+	withErr := len(results) > 0 && isErrorType(results[len(results)-1].Type)
+	if withErr {
+		results = results[:len(results)-1]
+	}
+
+	var body []jen.Code
+	var args []jen.Code
+	var argFields []jen.Code
+	for _, p := range params {
+		tp, err := qualifyType(p.Type, opts.importPath)
+		if err != nil {
+			return nil, err
+		}
+		arg := jen.Id("tt").Dot("args").Dot(p.Name)
+		if tp.Variadic {
+			elem := tp
+			elem.Variadic = false
+			tp = NewType("", ArrayTypeOption(elem))
+			arg.Op("...")
+		}
+		argFields = append(argFields, jen.Id(p.Name).Add(tp.Code()))
+		args = append(args, arg)
+	}
+	if len(argFields) > 0 {
+		body = append(body, jen.Type().Id("args").Struct(argFields...))
+	}
+	fields := []jen.Code{jen.Id("name").String()}
+	if fn.Recv != nil {
+		fields = append(fields, jen.Id("receiver").Add(recv.Code()))
+	}
+	if len(argFields) > 0 {
+		fields = append(fields, jen.Id("args").Id("args"))
+	}
+	var got []jen.Code
+	var checks []jen.Code
+	for i, r := range results {
+		suffix := ""
+		if i > 0 {
+			suffix = strconv.Itoa(i)
+		}
+		tp, err := qualifyType(r.Type, opts.importPath)
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, jen.Id("want"+suffix).Add(tp.Code()))
+		got = append(got, jen.Id("got"+suffix))
+		format := label + "() = %v, want %v"
+		if len(results) > 1 {
+			format = label + "() got" + suffix + " = %v, want %v"
+		}
+		checks = append(checks, jen.If(
+			jen.Op("!").Qual("reflect", "DeepEqual").Call(jen.Id("got"+suffix), jen.Id("tt").Dot("want"+suffix)),
+		).Block(
+			jen.Id("t").Dot("Errorf").Call(jen.Lit(format), jen.Id("got"+suffix), jen.Id("tt").Dot("want"+suffix)),
+		))
+	}
+	if withErr {
+		fields = append(fields, jen.Id("wantErr").Bool())
+		got = append(got, jen.Err())
+		errCheck := []jen.Code{jen.Id("t").Dot("Errorf").Call(jen.Lit(label+"() error = %v, wantErr %v"), jen.Err(), jen.Id("tt").Dot("wantErr"))}
+		if len(checks) > 0 {
+			errCheck = append(errCheck, jen.Return())
+		}
+		checks = append([]jen.Code{jen.If(jen.Parens(jen.Err().Op("!=").Nil()).Op("!=").Id("tt").Dot("wantErr")).Block(errCheck...)}, checks...)
+	}
+	body = append(body, jen.Id("tests").Op(":=").Index().Struct(fields...).Block(jen.Comment("TODO: Add test cases.")))
+
+	call := testCall(fn, jen.Id("tt").Dot("receiver"), args, opts)
+	run := []jen.Code{call}
+	if len(got) > 0 {
+		run = []jen.Code{jen.List(got...).Op(":=").Add(call)}
+	}
+	body = append(body, jen.For(jen.List(jen.Id("_"), jen.Id("tt")).Op(":=").Range().Id("tests")).Block(
+		jen.Id("t").Dot("Run").Call(jen.Id("tt").Dot("name"), jen.Func().Params(jen.Id("t").Op("*").Qual("testing", "T")).Block(
+			append(run, checks...)...,
+		)),
+	))
+	return NewFunction(
+		testName("Test", fn),
+		ParamsFunctionOption(*NewParameter("t", NewType("T", ImportTypeOption(Import{Path: "testing"}), PointerTypeOption()))),
+		BodyFunctionOption(body...),
+	), nil
+}
+
+// testName returns the name of the test of the function with the prefix (e.x TestSum, Test_sum or TestUser_Name).
+func testName(prefix string, fn *Function) string {
+	if fn.Recv != nil {
+		return prefix + fn.Recv.Type.Qualifier + "_" + fn.Name
+	}
+	if !isExported(fn.Name) {
+		return prefix + "_" + fn.Name
+	}
+	return prefix + fn.Name
+}
+
+// testReceiver returns the receiver type of the tested method, it checks that the function can be tested
+// in an external test package if ImportTestOption is set.
+func testReceiver(fn *Function, opts *testOptions) (Type, error) {
+	if opts.importPath != "" && !isExported(fn.Name) {
+		return Type{}, fmt.Errorf("the function %s is not exported and can not be tested in an external test package", fn.Name)
+	}
+	if fn.Recv == nil {
+		return Type{}, nil
+	}
+	return qualifyType(fn.Recv.Type, opts.importPath)
+}
+
+// testCall returns the call of the tested function, methods are called on the receiver.
+func testCall(fn *Function, receiver *jen.Statement, args []jen.Code, opts *testOptions) *jen.Statement {
+	switch {
+	case fn.Recv != nil:
+		return receiver.Dot(fn.Name).Call(args...)
+	case opts.importPath != "":
+		return jen.Qual(opts.importPath, fn.Name).Call(args...)
+	}
+	return jen.Id(fn.Name).Call(args...)
+}
+
+// qualifyType returns a copy of the type where the types declared in the package use the import path,
+// the type is returned as it is if the path is empty.
+// An error is returned if the type uses an unexported type of the package.
+func qualifyType(tp Type, path string) (Type, error) {
+	c := tp.Clone()
+	if path == "" {
+		return c, nil
+	}
+	return c, qualifyTypeIn(&c, path)
+}
+
+func qualifyTypeIn(tp *Type, path string) error {
+	for i := range tp.TypeArgs {
+		if err := qualifyTypeIn(&tp.TypeArgs[i], path); err != nil {
+			return err
+		}
+	}
+	switch {
+	case tp.RawType != nil:
+	case tp.ArrayType != nil:
+		return qualifyTypeIn(tp.ArrayType, path)
+	case tp.MapType != nil:
+		if err := qualifyTypeIn(&tp.MapType.Key, path); err != nil {
+			return err
+		}
+		return qualifyTypeIn(&tp.MapType.Value, path)
+	case tp.Function != nil:
+		for _, params := range [][]Parameter{tp.Function.Params, tp.Function.Results} {
+			for i := range params {
+				if err := qualifyTypeIn(&params[i].Type, path); err != nil {
+					return err
+				}
+			}
+		}
+	case tp.Struct != nil:
+		for i := range tp.Struct.Fields {
+			if err := qualifyTypeIn(&tp.Struct.Fields[i].Type, path); err != nil {
+				return err
+			}
+		}
+	case tp.Import == nil && tp.Qualifier != "":
+		// the predeclared types have a zero value that is known without an import.
+		if _, ok := zeroValue(NewType(tp.Qualifier)); ok {
+			return nil
+		}
+		if !isExported(tp.Qualifier) {
+			return fmt.Errorf("the type %s is not exported and can not be used in an external test package", tp.Qualifier)
+		}
+		tp.Import = &Import{Path: path}
+	}
+	return nil
+}
@@ -0,0 +1,211 @@
+package code
+
+import (
+	"testing"
+)
+
+func TestNewTestFile(t *testing.T) {
+	user := NewStruct("User")
+	name := NewFunction(
+		"Name",
+		ParamsFunctionOption(*NewParameter("prefix", NewType("string"))),
+		ResultsFunctionOption(*NewParameter("", NewType("string")), *NewParameter("", NewType("error"))),
+	)
+	user.AddMethod(name)
+	tests := []struct {
+		name        string
+		packageName string
+		fn          *Function
+		options     []TestOptions
+		want        string
+		wantErr     bool
+	}{
+		{
+			name:        "Should create the test of a function",
+			packageName: "foo",
+			fn: NewFunction(
+				"Sum",
+				ParamsFunctionOption(
+					*NewParameter("a", NewType("int")),
+					*NewParameter("", NewType("Duration", ImportTypeOption(*NewImport("", "time")), VariadicTypeOption())),
+				),
+				ResultsFunctionOption(*NewParameter("", NewType("int")), *NewParameter("", NewType("", ArrayTypeOption(NewType("User"))))),
+			),
+			want: `package foo
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSum(t *testing.T) {
+	type args struct {
+		a    int
+		arg1 []time.Duration
+	}
+	tests := []struct {
+		name  string
+		args  args
+		want  int
+		want1 []User
+	}{
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, got1 := Sum(tt.args.a, tt.args.arg1...)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Sum() got = %v, want %v", got, tt.want)
+			}
+			if !reflect.DeepEqual(got1, tt.want1) {
+				t.Errorf("Sum() got1 = %v, want %v", got1, tt.want1)
+			}
+		})
+	}
+}
+`,
+		},
+		{
+			name:        "Should create the test of an unexported function without results",
+			packageName: "foo",
+			fn:          NewFunction("reset"),
+			want: `package foo
+
+import "testing"
+
+func Test_reset(t *testing.T) {
+	tests := []struct {
+		name string
+	}{
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reset()
+		})
+	}
+}
+`,
+		},
+		{
+			name:        "Should create the test of a method in an external test package",
+			packageName: "foo_test",
+			fn:          name,
+			options:     []TestOptions{ImportTestOption("github.com/test/foo")},
+			want: `package foo_test
+
+import (
+	foo "github.com/test/foo"
+	"reflect"
+	"testing"
+)
+
+func TestUser_Name(t *testing.T) {
+	type args struct {
+		prefix string
+	}
+	tests := []struct {
+		name     string
+		receiver *foo.User
+		args     args
+		want     string
+		wantErr  bool
+	}{
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.receiver.Name(tt.args.prefix)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("User.Name() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("User.Name() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+`,
+		},
+		{
+			name:        "Should add the benchmark and the fuzz test",
+			packageName: "foo",
+			fn:          NewFunction("Check", ParamsFunctionOption(*NewParameter("ok", NewType("bool")))),
+			options:     []TestOptions{BenchmarkTestOption(), FuzzTestOption()},
+			want: `package foo
+
+import "testing"
+
+func TestCheck(t *testing.T) {
+	type args struct {
+		ok bool
+	}
+	tests := []struct {
+		name string
+		args args
+	}{
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			Check(tt.args.ok)
+		})
+	}
+}
+
+func BenchmarkCheck(b *testing.B) {
+	var ok bool
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Check(ok)
+	}
+}
+
+func FuzzCheck(f *testing.F) {
+	f.Add(false)
+	f.Add(true)
+	f.Fuzz(func(t *testing.T, ok bool) {
+		Check(ok)
+	})
+}
+`,
+		},
+		{
+			name:        "Should return an error for external test packages without the import path",
+			packageName: "foo_test",
+			fn:          NewFunction("Reset"),
+			wantErr:     true,
+		},
+		{
+			name:        "Should return an error for unexported functions in external test packages",
+			packageName: "foo_test",
+			fn:          NewFunction("reset"),
+			options:     []TestOptions{ImportTestOption("github.com/test/foo")},
+			wantErr:     true,
+		},
+		{
+			name:        "Should return an error for unexported types in external test packages",
+			packageName: "foo_test",
+			fn:          NewFunction("Reset", ParamsFunctionOption(*NewParameter("s", NewType("state", PointerTypeOption())))),
+			options:     []TestOptions{ImportTestOption("github.com/test/foo")},
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewTestFile(tt.packageName, tt.fn, tt.options...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewTestFile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if s := got.String(); s != tt.want {
+				t.Errorf("NewTestFile() = %v, want %v", s, tt.want)
+			}
+		})
+	}
+}
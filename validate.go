@@ -0,0 +1,321 @@
+package code
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/dave/jennifer/jen"
+)
+
+// ValidateOptions is used when you call AddValidate, it is a handy way to allow multiple configurations
+// for the generated validation methods.
+type ValidateOptions func(o *validateOptions)
+
+type validateOptions struct {
+	tagKey string
+	types  []string
+}
+
+// TagValidateOption sets the tag key of the validation rules, the default key is validate.
+func TagValidateOption(key string) ValidateOptions {
+	return func(o *validateOptions) {
+		o.tagKey = key
+	}
+}
+
+// TypesValidateOption sets the names of the package types that have generated validation methods,
+// fields of those types (also behind pointers, slices and maps) are validated recursively.
+func TypesValidateOption(names ...string) ValidateOptions {
+	return func(o *validateOptions) {
+		o.types = append(o.types, names...)
+	}
+}
+
+// AddValidate adds a Validate() error method to the structure and returns the added methods.
+//
+// The checks are generated from the validate tags of the fields (e.x `validate:"required,min=1,max=64,email"`),
+// the supported rules are:
+//   - required: the value is not the zero value (nil pointers, slices, maps and interfaces, "", 0, false or a zero time.Time)
+//   - min, max and len: the number of characters of strings, the number of items of slices and maps or the value of numbers
+//   - email: the string is a valid email address (net/mail)
+//   - oneof: the string or number is one of the space separated values (e.x oneof=admin user)
+//   - omitempty: the other rules are only checked if the value is not the zero value
+//
+// The rules of pointer fields are checked on the pointed value if the pointer is not nil.
+// Validate returns all the errors joined, every error starts with the path of the field (e.x Address.Street: is required).
+// Fields of the structure type or of a type set with TypesValidateOption are validated with their unexported
+// validate(path string) []error method which is also added to the structure.
+// An error is returned if a rule is unknown or is not supported by the field type.
+func AddValidate(st *Struct, options ...ValidateOptions) ([]*Function, error) {
+	opts := &validateOptions{
+		tagKey: "validate",
+		types:  []string{st.Name},
+	}
+	for _, o := range options {
+		o(opts)
+	}
+	recv := freeReceiverName(st.Name, "errs", "path", "err", "i", "k", "v")
+	body := []jen.Code{jen.Var().Id("errs").Index().Error()}
+	for _, f := range st.Fields {
+		name := fieldName(f)
+		value := func() *jen.Statement { return jen.Id(recv).Dot(name) }
+		checks, err := opts.checks(f, value)
+		if err != nil {
+			return nil, err
+		}
+		body = append(body, checks...)
+		body = append(body, opts.nested(value, "%s"+name, []jen.Code{jen.Id("path")}, f.Type, 0)...)
+	}
+	body = append(body, jen.Return(jen.Id("errs")))
+	methods := []*Function{
+		NewFunction(
+			"Validate",
+			ResultsFunctionOption(*NewParameter("", NewType("error"))),
+			BodyFunctionOption(jen.Return(jen.Qual("errors", "Join").Call(jen.Id(recv).Dot("validate").Call(jen.Lit("")).Op("...")))),
+			DocsFunctionOption(Comment("Validate checks the "+st.Name+" fields, it returns all the validation errors joined.")),
+		),
+		NewFunction(
+			"validate",
+			ParamsFunctionOption(*NewParameter("path", NewType("string"))),
+			ResultsFunctionOption(*NewParameter("", NewType("", ArrayTypeOption(NewType("error"))))),
+			BodyFunctionOption(body...),
+			DocsFunctionOption(Comment("validate returns the validation errors of the "+st.Name+" fields prefixed with the path.")),
+		),
+	}
+	for _, m := range methods {
+		st.AddMethod(m, ReceiverNameMethodOption(recv))
+	}
+	return methods, nil
+}
+
+// checks returns the code that checks the validation rules of the field.
+func (o *validateOptions) checks(f StructField, value func() *jen.Statement) ([]jen.Code, error) {
+	name := fieldName(f)
+	rules := tagOptions(f, o.tagKey)
+	if len(rules) == 0 {
+		return nil, nil
+	}
+	fail := func(message string) jen.Code {
+		return jen.Id("errs").Op("=").Append(
+			jen.Id("errs"),
+			jen.Qual("fmt", "Errorf").Call(jen.Lit("%s"+name+": "+message), jen.Id("path")),
+		)
+	}
+	tp := f.Type
+	elem := value
+	if tp.Pointer {
+		tp = tp.Clone()
+		tp.Pointer = false
+		elem = func() *jen.Statement { return jen.Op("*").Add(value()) }
+	}
+	var required jen.Code
+	var omitempty bool
+	var checks []jen.Code
+	for _, rule := range rules {
+		key, arg := rule, ""
+		if i := strings.Index(rule, "="); i != -1 {
+			key, arg = rule[:i], rule[i+1:]
+		}
+		unsupported := fmt.Errorf("the validate rule %s is not supported by the field %s", rule, name)
+		switch key {
+		case "required":
+			cond, ok := zeroCondition(f.Type, value, false)
+			if !ok {
+				return nil, unsupported
+			}
+			required = jen.If(cond).Block(fail("is required"))
+		case "omitempty":
+			omitempty = true
+		case "min", "max", "len":
+			cond, message, ok := boundCondition(key, arg, tp, elem)
+			if !ok {
+				return nil, unsupported
+			}
+			checks = append(checks, jen.If(cond).Block(fail(message)))
+		case "email":
+			if validateKind(tp) != "string" {
+				return nil, unsupported
+			}
+			checks = append(checks, jen.If(
+				jen.List(jen.Id("_"), jen.Err()).Op(":=").Qual("net/mail", "ParseAddress").Call(elem()),
+				jen.Err().Op("!=").Nil(),
+			).Block(fail("must be a valid email address")))
+		case "oneof":
+			kind := validateKind(tp)
+			if (kind != "string" && kind != "number") || arg == "" {
+				return nil, unsupported
+			}
+			var cond *jen.Statement
+			for _, v := range strings.Fields(arg) {
+				var c *jen.Statement
+				if kind == "string" {
+					c = elem().Op("!=").Lit(v)
+				} else if _, err := strconv.ParseFloat(v, 64); err == nil {
+					c = elem().Op("!=").Op(v)
+				} else {
+					return nil, unsupported
+				}
+				if cond == nil {
+					cond = c
+				} else {
+					cond = cond.Op("&&").Add(c)
+				}
+			}
+			checks = append(checks, jen.If(cond).Block(fail("must be one of "+strings.Join(strings.Fields(arg), " "))))
+		default:
+			return nil, fmt.Errorf("unknown validate rule %s of the field %s", rule, name)
+		}
+	}
+	if len(checks) > 0 && f.Type.Pointer {
+		checks = []jen.Code{jen.If(value().Op("!=").Nil()).Block(checks...)}
+	} else if len(checks) > 0 && omitempty {
+		cond, ok := zeroCondition(f.Type, value, true)
+		if !ok {
+			return nil, fmt.Errorf("the validate rule omitempty is not supported by the field %s", name)
+		}
+		checks = []jen.Code{jen.If(cond).Block(checks...)}
+	}
+	if required != nil {
+		checks = append([]jen.Code{required}, checks...)
+	}
+	return checks, nil
+}
+
+// nested returns the code that validates the fields of generated types,
+// the path of the errors is formatted with the format and the arguments (e.x "%sItems[%d]", path, i).
+func (o *validateOptions) nested(value func() *jen.Statement, format string, args []jen.Code, tp Type, depth int) []jen.Code {
+	switch {
+	case tp.Pointer:
+		elem := tp.Clone()
+		elem.Pointer = false
+		code := o.nested(value, format, args, elem, depth)
+		if len(code) == 0 {
+			return nil
+		}
+		return []jen.Code{jen.If(value().Op("!=").Nil()).Block(code...)}
+	case o.generated(tp):
+		var path jen.Code
+		if len(args) == 1 {
+			path = jen.Id("path").Op("+").Lit(strings.TrimPrefix(format, "%s") + ".")
+		} else {
+			path = jen.Qual("fmt", "Sprintf").Call(append([]jen.Code{jen.Lit(format + ".")}, args...)...)
+		}
+		return []jen.Code{jen.Id("errs").Op("=").Append(jen.Id("errs"), value().Dot("validate").Call(path).Op("..."))}
+	case tp.ArrayType != nil:
+		i := loopName("i", depth)
+		code := o.nested(
+			func() *jen.Statement { return value().Index(jen.Id(i)) },
+			format+"[%d]",
+			append(append([]jen.Code{}, args...), jen.Id(i)),
+			*tp.ArrayType,
+			depth+1,
+		)
+		if len(code) == 0 {
+			return nil
+		}
+		return []jen.Code{jen.For(jen.Id(i).Op(":=").Range().Add(value())).Block(code...)}
+	case tp.MapType != nil:
+		k, v := loopName("k", depth), loopName("v", depth)
+		code := o.nested(
+			func() *jen.Statement { return jen.Id(v) },
+			format+"[%v]",
+			append(append([]jen.Code{}, args...), jen.Id(k)),
+			tp.MapType.Value,
+			depth+1,
+		)
+		if len(code) == 0 {
+			return nil
+		}
+		return []jen.Code{jen.For(jen.List(jen.Id(k), jen.Id(v)).Op(":=").Range().Add(value())).Block(code...)}
+	}
+	return nil
+}
+
+// generated returns true if the type has generated validation methods.
+func (o *validateOptions) generated(tp Type) bool {
+	return tp.Import == nil && !tp.Pointer && tp.RawType == nil && tp.ArrayType == nil && tp.MapType == nil &&
+		tp.Function == nil && tp.Struct == nil && contains(o.types, tp.Qualifier)
+}
+
+// validateKind returns the kind of the type used by the validation rules (string, number, bool, length or time).
+func validateKind(tp Type) string {
+	if tp.Pointer || tp.RawType != nil || tp.Function != nil || tp.Struct != nil {
+		return ""
+	}
+	if tp.ArrayType != nil || tp.MapType != nil {
+		return "length"
+	}
+	if isTimeType(tp) {
+		return "time"
+	}
+	if tp.Import != nil {
+		return ""
+	}
+	switch tp.Qualifier {
+	case "string":
+		return "string"
+	case "bool":
+		return "bool"
+	case "int", "int8", "int16", "int32", "int64",
+		"uint", "uint8", "uint16", "uint32", "uint64",
+		"byte", "rune", "float32", "float64":
+		return "number"
+	}
+	return ""
+}
+
+// zeroCondition returns the condition that is true if the value is the zero value of the type,
+// if not is true the condition is true if the value is not the zero value.
+func zeroCondition(tp Type, value func() *jen.Statement, not bool) (*jen.Statement, bool) {
+	op, negate := "==", jen.Null()
+	if not {
+		op, negate = "!=", jen.Op("!")
+	}
+	if tp.Pointer || tp.Function != nil || (tp.Import == nil && (tp.Qualifier == "error" || tp.Qualifier == "any" || tp.Qualifier == "interface{}")) {
+		return value().Op(op).Nil(), true
+	}
+	switch validateKind(tp) {
+	case "string":
+		return value().Op(op).Lit(""), true
+	case "number":
+		return value().Op(op).Lit(0), true
+	case "bool":
+		if not {
+			return value(), true
+		}
+		return jen.Op("!").Add(value()), true
+	case "length":
+		return jen.Len(value()).Op(op).Lit(0), true
+	case "time":
+		return negate.Add(value()).Dot("IsZero").Call(), true
+	}
+	return nil, false
+}
+
+// boundCondition returns the condition that is true if the min, max or len rule fails and the error message.
+func boundCondition(rule, arg string, tp Type, value func() *jen.Statement) (*jen.Statement, string, bool) {
+	ops := map[string]string{"min": "<", "max": ">", "len": "!="}
+	words := map[string]string{"min": "at least", "max": "at most", "len": "exactly"}
+	switch validateKind(tp) {
+	case "string", "length":
+		n, err := strconv.Atoi(arg)
+		if err != nil {
+			return nil, "", false
+		}
+		if validateKind(tp) == "string" {
+			return jen.Qual("unicode/utf8", "RuneCountInString").Call(value()).Op(ops[rule]).Lit(n),
+				"must be " + words[rule] + " " + arg + " characters long", true
+		}
+		return jen.Len(value()).Op(ops[rule]).Lit(n), "must contain " + words[rule] + " " + arg + " items", true
+	case "number":
+		if _, err := strconv.ParseFloat(arg, 64); err != nil {
+			return nil, "", false
+		}
+		if rule == "len" {
+			return value().Op("!=").Op(arg), "must be " + arg, true
+		}
+		return value().Op(ops[rule]).Op(arg), "must be " + words[rule] + " " + arg, true
+	}
+	return nil, "", false
+}
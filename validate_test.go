@@ -0,0 +1,184 @@
+package code
+
+import (
+	"testing"
+)
+
+func TestAddValidate(t *testing.T) {
+	tag := func(v string) *FieldTags { return NewFieldTags("validate", v) }
+	tests := []struct {
+		name       string
+		structName string
+		fields     []StructField
+		options    []ValidateOptions
+		want       string
+		wantErr    bool
+	}{
+		{
+			name:       "Should check the validation rules",
+			structName: "User",
+			fields: []StructField{
+				*NewStructFieldWithTag("Name", NewType("string"), tag("required,max=64")),
+				*NewStructFieldWithTag("Email", NewType("string"), tag("omitempty,email")),
+				*NewStructFieldWithTag("Age", NewType("int", PointerTypeOption()), tag("required,min=18")),
+				*NewStructFieldWithTag("Role", NewType("string"), tag("oneof=admin user")),
+				*NewStructFieldWithTag("Tags", NewType("", ArrayTypeOption(NewType("string"))), tag("len=2")),
+			},
+			want: `package test
+
+import (
+	"errors"
+	"fmt"
+	"net/mail"
+	"unicode/utf8"
+)
+
+type User struct {
+	Name  string   ` + "`validate:\"required,max=64\"`" + `
+	Email string   ` + "`validate:\"omitempty,email\"`" + `
+	Age   *int     ` + "`validate:\"required,min=18\"`" + `
+	Role  string   ` + "`validate:\"oneof=admin user\"`" + `
+	Tags  []string ` + "`validate:\"len=2\"`" + `
+}
+
+// Validate checks the User fields, it returns all the validation errors joined.
+func (u *User) Validate() error {
+	return errors.Join(u.validate("")...)
+}
+
+// validate returns the validation errors of the User fields prefixed with the path.
+func (u *User) validate(path string) []error {
+	var errs []error
+	if u.Name == "" {
+		errs = append(errs, fmt.Errorf("%sName: is required", path))
+	}
+	if utf8.RuneCountInString(u.Name) > 64 {
+		errs = append(errs, fmt.Errorf("%sName: must be at most 64 characters long", path))
+	}
+	if u.Email != "" {
+		if _, err := mail.ParseAddress(u.Email); err != nil {
+			errs = append(errs, fmt.Errorf("%sEmail: must be a valid email address", path))
+		}
+	}
+	if u.Age == nil {
+		errs = append(errs, fmt.Errorf("%sAge: is required", path))
+	}
+	if u.Age != nil {
+		if *u.Age < 18 {
+			errs = append(errs, fmt.Errorf("%sAge: must be at least 18", path))
+		}
+	}
+	if u.Role != "admin" && u.Role != "user" {
+		errs = append(errs, fmt.Errorf("%sRole: must be one of admin user", path))
+	}
+	if len(u.Tags) != 2 {
+		errs = append(errs, fmt.Errorf("%sTags: must contain exactly 2 items", path))
+	}
+	return errs
+}
+`,
+		},
+		{
+			name:       "Should validate the nested generated types",
+			structName: "User",
+			fields: []StructField{
+				*NewStructFieldWithTag("Address", NewType("Address", PointerTypeOption()), tag("required")),
+				*NewStructField("Others", NewType("", ArrayTypeOption(NewType("Address")))),
+				*NewStructField("Parent", NewType("User")),
+			},
+			options: []ValidateOptions{TypesValidateOption("Address")},
+			want: `package test
+
+import (
+	"errors"
+	"fmt"
+)
+
+type User struct {
+	Address *Address ` + "`validate:\"required\"`" + `
+	Others  []Address
+	Parent  User
+}
+
+// Validate checks the User fields, it returns all the validation errors joined.
+func (u *User) Validate() error {
+	return errors.Join(u.validate("")...)
+}
+
+// validate returns the validation errors of the User fields prefixed with the path.
+func (u *User) validate(path string) []error {
+	var errs []error
+	if u.Address == nil {
+		errs = append(errs, fmt.Errorf("%sAddress: is required", path))
+	}
+	if u.Address != nil {
+		errs = append(errs, u.Address.validate(path+"Address.")...)
+	}
+	for i := range u.Others {
+		errs = append(errs, u.Others[i].validate(fmt.Sprintf("%sOthers[%d].", path, i))...)
+	}
+	errs = append(errs, u.Parent.validate(path+"Parent.")...)
+	return errs
+}
+`,
+		},
+		{
+			name:       "Should not use the loop variables as the receiver",
+			structName: "Item",
+			fields:     []StructField{*NewStructField("Children", NewType("", ArrayTypeOption(NewType("Item"))))},
+			options:    []ValidateOptions{TypesValidateOption("Item")},
+			want: `package test
+
+import (
+	"errors"
+	"fmt"
+)
+
+type Item struct {
+	Children []Item
+}
+
+// Validate checks the Item fields, it returns all the validation errors joined.
+func (item *Item) Validate() error {
+	return errors.Join(item.validate("")...)
+}
+
+// validate returns the validation errors of the Item fields prefixed with the path.
+func (item *Item) validate(path string) []error {
+	var errs []error
+	for i := range item.Children {
+		errs = append(errs, item.Children[i].validate(fmt.Sprintf("%sChildren[%d].", path, i))...)
+	}
+	return errs
+}
+`,
+		},
+		{
+			name:       "Should return an error for unknown rules",
+			structName: "User",
+			fields:     []StructField{*NewStructFieldWithTag("Name", NewType("string"), tag("uuid"))},
+			wantErr:    true,
+		},
+		{
+			name:       "Should return an error for rules not supported by the field type",
+			structName: "User",
+			fields:     []StructField{*NewStructFieldWithTag("Age", NewType("int"), tag("email"))},
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := NewStructWithFields(tt.structName, tt.fields)
+			_, err := AddValidate(st, tt.options...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("AddValidate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got := NewFile("test", st).String(); got != tt.want {
+				t.Errorf("AddValidate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}